@@ -0,0 +1,185 @@
+package assts
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/audit"
+	"github.com/jackitaliano/oait/internal/backup"
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/protect"
+	"github.com/jackitaliano/oait/internal/spec"
+	"github.com/jackitaliano/oait/internal/tui"
+
+	"github.com/akamensky/argparse"
+)
+
+type ApplyCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	inputArg     *string
+	orgArg       *string
+	matchKeyArg  *string
+	pruneFlag    *bool
+	dryRunFlag   *bool
+	yesFlag      *bool
+	maxDeleteArg *int
+}
+
+func NewApplyCommand(command *argparse.Command) *ApplyCommand {
+	const name = "apply"
+	const desc = "Apply Assistant Specs"
+
+	subCommand := command.NewCommand(name, desc)
+
+	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: true, Help: "Spec file or directory (.json | .yaml | .yml)"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	matchKeyArg := subCommand.String("", "match-key", &argparse.Options{Required: false, Help: "Match specs to assts by this metadata key instead of name"})
+	pruneFlag := subCommand.Flag("", "prune", &argparse.Options{Required: false, Help: "Delete assts carrying --match-key not matched by any spec"})
+	dryRunFlag := subCommand.Flag("", "dry-run", &argparse.Options{Required: false, Help: "Output plan without applying"})
+	yesFlag := subCommand.Flag("y", "yes", &argparse.Options{Required: false, Help: "Answer yes to all prompts"})
+	maxDeleteArg := subCommand.Int("", "max-delete", &argparse.Options{Required: false, Help: "Abort if more than N assts would be pruned"})
+
+	return &ApplyCommand{
+		name,
+		desc,
+		subCommand,
+		inputArg,
+		orgArg,
+		matchKeyArg,
+		pruneFlag,
+		dryRunFlag,
+		yesFlag,
+		maxDeleteArg,
+	}
+}
+
+func (a *ApplyCommand) Happened() bool {
+
+	return a.command.Happened()
+}
+
+func (a *ApplyCommand) Run(key string) error {
+	if *a.pruneFlag && *a.matchKeyArg == "" { // Else every asst without a spec is pruned
+		err := errors.New("--prune requires --match-key, so only assts carrying the key are deleted")
+		return err
+	}
+
+	policy, err := protect.Load()
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Reading specs...\t\t")
+	specs, err := spec.Load(*a.inputArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Retrieving all assts...\t\t")
+	asstObjects, err := openai.RetrieveAllAssts(key, *a.orgArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Planning changes...\t\t")
+	changes, err := spec.Plan(specs, *asstObjects, *a.matchKeyArg, *a.pruneFlag, policy)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Outputting plan... \n\n")
+	fmt.Printf("%v\n\n", spec.Format(changes))
+
+	if *a.dryRunFlag || !spec.HasChanges(changes) {
+		return nil
+	}
+
+	createSpecs := []*spec.Asst{}
+	modifiedAssts := make(map[string]*openai.ModifiedAssistant)
+	deleteAsstIDs := []string{}
+
+	for _, change := range changes {
+		switch change.Action {
+		case spec.Create:
+			createSpecs = append(createSpecs, change.Spec)
+		case spec.Update:
+			modifiedAssts[change.Live.ID] = change.Modified
+		case spec.Delete:
+			deleteAsstIDs = append(deleteAsstIDs, change.Live.ID)
+		}
+	}
+
+	maxDeleteParsed := a.command.GetArgs()[7].GetParsed()
+
+	if maxDeleteParsed && len(deleteAsstIDs) > *a.maxDeleteArg {
+		errMsg := fmt.Sprintf("Refusing to delete %v assts: more than --max-delete %v", len(deleteAsstIDs), *a.maxDeleteArg)
+		err := errors.New(errMsg)
+		return err
+	}
+
+	confirmed, err := tui.Confirm("Apply plan", *a.yesFlag)
+
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		fmt.Printf("Cancelled.\n")
+		return nil
+	}
+
+	appliedIDs := []string{}
+	for asstID := range modifiedAssts {
+		appliedIDs = append(appliedIDs, asstID)
+	}
+	appliedIDs = append(appliedIDs, deleteAsstIDs...)
+
+	audit.LogConfirmed("assts apply", *a.orgArg, appliedIDs, *a.yesFlag)
+
+	for _, createSpec := range createSpecs {
+		fmt.Printf("Creating asst %q...\t", createSpec.Name)
+		asstObject, err := openai.CreateAssistant(key, createSpec.ToCreated(), *a.orgArg)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+		fmt.Printf("✓\n")
+		fmt.Printf("Created asst: %v\n", asstObject.ID)
+	}
+
+	if len(modifiedAssts) > 0 {
+		fmt.Printf("Updating assts...\t\t")
+		numUpdated := openai.UpdateAssts(key, modifiedAssts, *a.orgArg)
+		fmt.Printf("✓\n")
+		fmt.Printf("Updated %v assts.\n", numUpdated)
+	}
+
+	if len(deleteAsstIDs) > 0 {
+		err = backup.Before(key, "assts", deleteAsstIDs, *a.orgArg, "")
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Deleting assts...\t\t")
+		numDeleted := openai.DeleteAssts(key, deleteAsstIDs, *a.orgArg)
+		fmt.Printf("✓\n")
+		fmt.Printf("Deleted %v assts.\n", numDeleted)
+	}
+
+	return nil
+}
@@ -0,0 +1,340 @@
+package assts
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/audit"
+	"github.com/jackitaliano/oait/internal/clone"
+	"github.com/jackitaliano/oait/internal/config"
+	"github.com/jackitaliano/oait/internal/filter"
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/tui"
+
+	"github.com/akamensky/argparse"
+)
+
+type CloneCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	asstsArg           *[]string
+	inputArg           *string
+	allFlag            *bool
+	fromOrgArg         *string
+	toOrgArg           *string
+	toKeyArg           *string
+	toKeyFileArg       *string
+	toProfileArg       *string
+	timeLTEArg         *float64
+	timeGTArg          *float64
+	nameContainsArg    *[]string
+	nameNotContainsArg *[]string
+	outputArg          *string
+	yesFlag            *bool
+}
+
+func NewCloneCommand(command *argparse.Command) *CloneCommand {
+	const name = "clone"
+	const desc = "Clone Assistants Across Orgs"
+
+	subCommand := command.NewCommand(name, desc)
+
+	asstsArg := subCommand.StringList("i", "ids", &argparse.Options{Required: false, Help: "List of Asst IDs"})
+	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: false, Help: "Asst File Input (of ids)"})
+	allFlag := subCommand.Flag("A", "all", &argparse.Options{Required: false, Help: "Clone all assts"})
+	fromOrgArg := subCommand.String("", "from-org", &argparse.Options{Required: false, Help: "Organization ID to clone from"})
+	toOrgArg := subCommand.String("", "to-org", &argparse.Options{Required: false, Help: "Organization ID to clone to"})
+	toKeyArg := subCommand.String("", "to-key", &argparse.Options{Required: false, Help: "OpenAI API Key to clone to (default to --key; prefer --to-key-file or --to-profile, as this ends up in shell history)"})
+	toKeyFileArg := subCommand.String("", "to-key-file", &argparse.Options{Required: false, Help: "Read the OpenAI API Key to clone to from a file ('-' for stdin)"})
+	toProfileArg := subCommand.String("", "to-profile", &argparse.Options{Required: false, Help: "Config profile to clone to (its key, and its org unless --to-org)"})
+	timeLTEArg := subCommand.Float("d", "days", &argparse.Options{Required: false, Help: "Filter by LTE to days"})
+	timeGTArg := subCommand.Float("D", "Days", &argparse.Options{Required: false, Help: "Filter by GT days"})
+	nameContainsArg := subCommand.StringList("n", "name", &argparse.Options{Required: false, Help: "Filter by Asst containing name"})
+	nameNotContainsArg := subCommand.StringList("N", "Name", &argparse.Options{Required: false, Help: "Filter by Asst not containing name"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Migration Report File Output (JSON)"})
+	yesFlag := subCommand.Flag("y", "yes", &argparse.Options{Required: false, Help: "Answer yes to all prompts"})
+
+	return &CloneCommand{
+		name,
+		desc,
+		subCommand,
+		asstsArg,
+		inputArg,
+		allFlag,
+		fromOrgArg,
+		toOrgArg,
+		toKeyArg,
+		toKeyFileArg,
+		toProfileArg,
+		timeLTEArg,
+		timeGTArg,
+		nameContainsArg,
+		nameNotContainsArg,
+		outputArg,
+		yesFlag,
+	}
+}
+
+func (c *CloneCommand) Happened() bool {
+
+	return c.command.Happened()
+}
+
+func (c *CloneCommand) Run(key string) error {
+	args := c.command.GetArgs()
+	allParsed := args[3].GetParsed()
+
+	from := clone.Endpoint{Key: key, OrgID: *c.fromOrgArg}
+	to, err := c.getTo(key)
+
+	if err != nil {
+		return err
+	}
+
+	if from == to {
+		errMsg := fmt.Sprintf("Nothing to clone between: pass --from-org, --to-org, --to-profile or --to-key-file to `%v`\n", c.name)
+		err := errors.New(errMsg)
+		return err
+	}
+
+	var asstObjects *[]openai.AsstObject
+
+	if allParsed && *c.allFlag {
+		fmt.Printf("Retrieving all assts...\t\t")
+		asstObjects, err = openai.RetrieveAllAssts(from.Key, from.OrgID)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+
+		fmt.Printf("✓\n")
+
+	} else {
+
+		fmt.Printf("Retrieving asst ids...\t")
+		asstIDs, err := c.getAsstIDs(&args)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+		fmt.Printf("✓\n")
+
+		fmt.Printf("Retrieving assts...\t\t")
+		asstObjects = openai.RetrieveAssts(from.Key, asstIDs, from.OrgID)
+		fmt.Printf("✓\n")
+	}
+
+	fmt.Printf("Filtering assts...\t\t")
+	filteredAsstObjects, err := c.filterAssts(&args, asstObjects)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	cloneAsstObjects := []openai.AsstObject{}
+	cloneAsstIDs := []string{}
+
+	for _, asstObject := range *filteredAsstObjects {
+		if asstObject.ID == "" { // Failed to retrieve
+			continue
+		}
+
+		cloneAsstObjects = append(cloneAsstObjects, asstObject)
+		cloneAsstIDs = append(cloneAsstIDs, asstObject.ID)
+		fmt.Printf("\t%v (%v)\n", asstObject.ID, asstObject.Name)
+	}
+
+	if len(cloneAsstObjects) < 1 {
+		fmt.Printf("No assts to clone.\n")
+		return nil
+	}
+
+	confirmMsg := fmt.Sprintf("Confirm clone of %v assts (with their files and vector stores)", len(cloneAsstObjects))
+	confirmed, err := tui.Confirm(confirmMsg, *c.yesFlag)
+
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		fmt.Printf("Cancelled.\n")
+		return nil
+	}
+
+	audit.LogConfirmed("assts clone", to.OrgID, cloneAsstIDs, *c.yesFlag)
+
+	fmt.Printf("Cloning assts...\t\t")
+	report := clone.Assts(from, to, cloneAsstObjects)
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Outputting migration report... \n\n")
+	fmt.Printf("%v\n\n", report.Format())
+	fmt.Printf("Cloned %v/%v assts.\n", report.NumCloned(), len(cloneAsstObjects))
+
+	if *c.outputArg != "" {
+		reportOutput, err := io.ObjToJSON(report)
+
+		if err != nil {
+			return err
+		}
+
+		err = io.FileOutput(*c.outputArg, &reportOutput)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if report.NumCloned() < len(cloneAsstObjects) {
+		errMsg := fmt.Sprintf("Failed to clone %v assts", len(cloneAsstObjects)-report.NumCloned())
+		err := errors.New(errMsg)
+		return err
+	}
+
+	return nil
+}
+
+// getTo is the endpoint to clone to: --key and --to-org, with the key (and org)
+// swapped for whichever of --to-key, --to-key-file or --to-profile is passed.
+func (c *CloneCommand) getTo(key string) (clone.Endpoint, error) {
+	to := clone.Endpoint{Key: key, OrgID: *c.toOrgArg}
+
+	passed := 0
+	for _, arg := range []string{*c.toKeyArg, *c.toKeyFileArg, *c.toProfileArg} {
+		if arg != "" {
+			passed++
+		}
+	}
+
+	if passed > 1 {
+		errMsg := fmt.Sprintf("Pass only one of --to-key, --to-key-file or --to-profile to `%v`\n", c.name)
+		err := errors.New(errMsg)
+		return to, err
+	}
+
+	if *c.toKeyArg != "" {
+		to.Key = *c.toKeyArg
+
+	} else if *c.toKeyFileArg != "" {
+		if *c.toKeyFileArg == "-" && tui.ConfirmedBy(*c.yesFlag) == "prompt" {
+			err := errors.New("--to-key-file - needs --yes (or OAIT_ASSUME_YES=1), as the prompts can't read stdin once it's the key\n")
+			return to, err
+		}
+
+		toKey, err := config.ReadKeyFile(*c.toKeyFileArg)
+
+		if err != nil {
+			return to, err
+		}
+
+		to.Key = toKey
+
+	} else if *c.toProfileArg != "" {
+		cfg, err := config.Load()
+
+		if err != nil {
+			return to, err
+		}
+
+		name, profile, err := cfg.Resolve(*c.toProfileArg)
+
+		if err != nil {
+			return to, err
+		}
+
+		toKey, err := profile.APIKey()
+
+		if err != nil {
+			return to, err
+		}
+
+		if toKey == "" {
+			errMsg := fmt.Sprintf("Profile '%v' has no key to clone to", name)
+			err := errors.New(errMsg)
+			return to, err
+		}
+
+		to.Key = toKey
+
+		if to.OrgID == "" {
+			to.OrgID = profile.Org
+		}
+	}
+
+	return to, nil
+}
+
+func (c *CloneCommand) getAsstIDs(args *[]argparse.Arg) ([]string, error) {
+	asstsParsed := (*args)[1].GetParsed()
+	inputParsed := (*args)[2].GetParsed()
+
+	if asstsParsed { // List passed
+		asstIDs, err := io.ListInput(*c.asstsArg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return asstIDs, nil
+
+	}
+
+	if inputParsed { // Asst input passed
+		asstIDs, err := io.FileInput(*c.inputArg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return asstIDs, nil
+	}
+
+	errMsg := fmt.Sprintf("No input options passed to `%v`\n", c.name)
+	err := errors.New(errMsg)
+
+	return nil, err
+}
+
+func (c *CloneCommand) filterAssts(args *[]argparse.Arg, asstObjects *[]openai.AsstObject) (*[]openai.AsstObject, error) {
+	timeLTEParsed := (*args)[9].GetParsed()
+	timeGTParsed := (*args)[10].GetParsed()
+	nameContainsParsed := (*args)[11].GetParsed()
+	nameNotContainsParsed := (*args)[12].GetParsed()
+
+	filtered := asstObjects
+	var err error
+
+	if timeLTEParsed {
+		filtered, err = filter.DaysLTE(filtered, *c.timeLTEArg)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if timeGTParsed {
+		filtered, err = filter.DaysGT(filtered, *c.timeGTArg)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if nameContainsParsed {
+		filtered = filter.ContainsName(filtered, *c.nameContainsArg)
+
+	}
+
+	if nameNotContainsParsed {
+		filtered = filter.NotContainsName(filtered, *c.nameNotContainsArg)
+	}
+
+	return filtered, nil
+}
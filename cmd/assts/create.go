@@ -3,9 +3,13 @@ package assts
 import (
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/jackitaliano/oait/internal/audit"
 	"github.com/jackitaliano/oait/internal/io"
 	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/schema"
+	"github.com/jackitaliano/oait/internal/spec"
 	"github.com/jackitaliano/oait/internal/tui"
 
 	"github.com/akamensky/argparse"
@@ -16,15 +20,26 @@ type CreateCommand struct {
 	desc    string
 	command *argparse.Command
 
-	asstName *string
-	asstDesc *string
-	instruct *string
-	model    *string
-	temp     *float64
-	topP     *float64
-	resForm  *string
-	inputArg *string
-	orgArg   *string
+	asstName        *string
+	asstDesc        *string
+	instruct        *string
+	model           *string
+	temp            *float64
+	topP            *float64
+	resForm         *string
+	inputArg        *string
+	orgArg          *string
+	yesFlag         *bool
+	noVerify        *bool
+	toolFile        *[]string
+	toolArg         *[]string
+	vectorStoreArg  *[]string
+	codeFileArg     *[]string
+	metadataArg     *[]string
+	jsonSchemaArg   *string
+	instructFileArg *string
+	specArg         *string
+	strictModelFlag *bool
 }
 
 func NewCreateCommand(command *argparse.Command) *CreateCommand {
@@ -39,9 +54,20 @@ func NewCreateCommand(command *argparse.Command) *CreateCommand {
 	model := subCommand.String("m", "model", &argparse.Options{Required: false, Help: "OpenAI Model for assistant", Default: "gpt-3.5-turbo"})
 	temp := subCommand.Float("t", "temp", &argparse.Options{Required: false, Help: "Temperature of assistant <0.0 - 2.0>", Default: 1.0})
 	topP := subCommand.Float("T", "topp", &argparse.Options{Required: false, Help: "Top P of assistant <0.0 - 1.0>", Default: 1.0})
-	resForm := subCommand.String("r", "resformat", &argparse.Options{Required: false, Help: "Response format of assistant <'json_object' | 'auto'>", Default: "auto"})
+	resForm := subCommand.String("r", "resformat", &argparse.Options{Required: false, Help: "Response format of assistant <'auto' | 'text' | 'json_object'>", Default: "auto"})
 	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: false, Help: "Asst File Input"})
 	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	yesFlag := subCommand.Flag("y", "yes", &argparse.Options{Required: false, Help: "Answer yes to all prompts"})
+	noVerifyFlag := subCommand.Flag("", "no-verify", &argparse.Options{Required: false, Help: "Skip verification prompt"})
+	toolFile := subCommand.StringList("", "tool-file", &argparse.Options{Required: false, Help: "Add function tool from JSON file (function definition with JSON Schema parameters)"})
+	toolArg := subCommand.StringList("", "tool", &argparse.Options{Required: false, Help: "Add tool <code_interpreter | file_search | function:<file>>"})
+	vectorStoreArg := subCommand.StringList("", "vector-store", &argparse.Options{Required: false, Help: "Vector store ID for file_search (adds the tool)"})
+	codeFileArg := subCommand.StringList("", "code-file", &argparse.Options{Required: false, Help: "File ID for code_interpreter (adds the tool)"})
+	metadataArg := subCommand.StringList("", "meta", &argparse.Options{Required: false, Help: "Set metadata <key>=<value>"})
+	jsonSchemaArg := subCommand.String("", "json-schema", &argparse.Options{Required: false, Help: "Respond with json_schema response format from JSON Schema file"})
+	instructFileArg := subCommand.String("", "instructions-file", &argparse.Options{Required: false, Help: "Read instructions for assistant from file"})
+	specArg := subCommand.String("s", "spec", &argparse.Options{Required: false, Help: "Create assistants from spec file or directory (.json | .yaml | .yml)"})
+	strictModelFlag := subCommand.Flag("", "strict-model", &argparse.Options{Required: false, Help: "Refuse unknown model IDs instead of warning"})
 
 	return &CreateCommand{
 		name,
@@ -56,6 +82,17 @@ func NewCreateCommand(command *argparse.Command) *CreateCommand {
 		resForm,
 		inputArg,
 		orgArg,
+		yesFlag,
+		noVerifyFlag,
+		toolFile,
+		toolArg,
+		vectorStoreArg,
+		codeFileArg,
+		metadataArg,
+		jsonSchemaArg,
+		instructFileArg,
+		specArg,
+		strictModelFlag,
 	}
 }
 
@@ -67,17 +104,32 @@ func (c *CreateCommand) Happened() bool {
 func (c *CreateCommand) Run(key string) error {
 	args := c.command.GetArgs()
 
-	createdAsst, err := c.getCreatedAssistant(&args)
+	createdAssts, err := c.getCreatedAssistants(&args)
 
 	if err != nil {
 		return err
 	}
 
-	verify := verifyBeforeCreate()
+	modelIDs := []string{}
+	for _, createdAsst := range createdAssts {
+		modelIDs = append(modelIDs, createdAsst.Model)
+	}
+
+	err = checkModels(key, *c.orgArg, modelIDs, *c.strictModelFlag)
+
+	if err != nil {
+		return err
+	}
+
+	verify, err := verifyBeforeCreate(*c.noVerify, *c.yesFlag)
+
+	if err != nil {
+		return err
+	}
 
 	if verify {
 		fmt.Printf("Formatting assts output...\t")
-		asstsOutput, err := c.getCreatedAsstOutput(&args, createdAsst)
+		asstsOutput, err := c.getCreatedAsstOutput(&args, createdAssts)
 
 		if err != nil {
 			fmt.Printf("X\n")
@@ -93,9 +145,18 @@ func (c *CreateCommand) Run(key string) error {
 		}
 	}
 
-	confirmed := confirmCreate()
+	confirmed, err := confirmCreate(*c.yesFlag)
+
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		fmt.Printf("Cancelled.\n")
+		return nil
+	}
 
-	if confirmed {
+	for _, createdAsst := range createdAssts {
 		fmt.Printf("Creating assistant...\t\t")
 		asstObject, err := openai.CreateAssistant(key, createdAsst, *c.orgArg)
 
@@ -105,6 +166,8 @@ func (c *CreateCommand) Run(key string) error {
 		}
 
 		fmt.Printf("✓\n")
+		audit.LogConfirmed("assts create", *c.orgArg, []string{asstObject.ID}, *c.yesFlag)
+
 		fmt.Printf("Formatting assts output...\t")
 		asstsOutput, err := c.getAsstOutput(&args, asstObject)
 
@@ -120,24 +183,60 @@ func (c *CreateCommand) Run(key string) error {
 		if err != nil {
 			return err
 		}
-
-	} else {
-		fmt.Printf("Cancelled.\n")
 	}
 
 	return nil
 }
 
-func verifyBeforeCreate() bool {
-	return tui.YesNoLoop("Verify assistants before creation?")
+func verifyBeforeCreate(noVerify bool, assumeYes bool) (bool, error) {
+	if noVerify {
+		return false, nil
+	}
+
+	return tui.Confirm("Verify assistants before creation?", assumeYes)
 }
 
-func confirmCreate() bool {
-	return tui.YesNoLoop("Confirm creation")
+func confirmCreate(assumeYes bool) (bool, error) {
+	return tui.Confirm("Confirm creation", assumeYes)
 }
+
+func (c *CreateCommand) getCreatedAssistants(args *[]argparse.Arg) ([]*openai.CreatedAssistant, error) {
+	specParsed := (*args)[19].GetParsed()
+
+	if !specParsed {
+		createdAssistant, err := c.getCreatedAssistant(args)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []*openai.CreatedAssistant{createdAssistant}, nil
+	}
+
+	specs, err := spec.Load(*c.specArg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	createdAssistants := make([]*openai.CreatedAssistant, len(specs))
+
+	for i, s := range specs {
+		createdAssistants[i] = s.ToCreated()
+	}
+
+	return createdAssistants, nil
+}
+
 func (c *CreateCommand) getCreatedAssistant(args *[]argparse.Arg) (*openai.CreatedAssistant, error) {
 	inputParsed := (*args)[8].GetParsed()
 
+	tools, err := c.getTools()
+
+	if err != nil {
+		return nil, err
+	}
+
 	if inputParsed {
 		createdAssistant, err := io.JSONInput[openai.CreatedAssistant](*c.inputArg)
 		if err != nil {
@@ -149,6 +248,13 @@ func (c *CreateCommand) getCreatedAssistant(args *[]argparse.Arg) (*openai.Creat
 			return nil, err
 		}
 
+		createdAssistant.Tools = append(createdAssistant.Tools, tools...)
+
+		err = schema.ValidateTools(createdAssistant.Tools)
+		if err != nil {
+			return nil, err
+		}
+
 		return createdAssistant, nil
 	}
 
@@ -157,22 +263,149 @@ func (c *CreateCommand) getCreatedAssistant(args *[]argparse.Arg) (*openai.Creat
 		return nil, err
 	}
 
+	instructions := *c.instruct
+
+	if *c.instructFileArg != "" {
+		if instructions != "" {
+			err := errors.New("Give only one of --instruct and --instructions-file.")
+			return nil, err
+		}
+
+		instructions, err = io.TextInput(*c.instructFileArg)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resFormat, err := c.getResFormat()
+
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := patchMetadata(nil, *c.metadataArg)
+
+	if err != nil {
+		return nil, err
+	}
+
 	createdAssistant := openai.CreatedAssistant{
-		Name:         *c.asstName,
-		Description:  *c.asstDesc,
-		Instructions: *c.instruct,
-		Model:        *c.model,
-		Temp:         *c.temp,
-		TopP:         *c.topP,
-		ResFormat:    *c.resForm,
+		Name:          *c.asstName,
+		Description:   *c.asstDesc,
+		Instructions:  instructions,
+		Model:         *c.model,
+		Temp:          c.temp,
+		TopP:          c.topP,
+		ResFormat:     resFormat,
+		Tools:         tools,
+		ToolResources: c.getToolResources(),
+		Metadata:      metadata,
 	}
 
 	return &createdAssistant, nil
 }
 
-func (c *CreateCommand) getCreatedAsstOutput(args *[]argparse.Arg, asstObject *openai.CreatedAssistant) (*[]byte, error) {
+func (c *CreateCommand) getTools() ([]openai.Tool, error) {
+	tools := []openai.Tool{}
+	toolFiles := *c.toolFile
 
-	asstsOutput, err := io.ObjToJSON(asstObject)
+	for _, toolStr := range *c.toolArg {
+		if strings.HasPrefix(toolStr, "function:") {
+			toolFiles = append(toolFiles, strings.TrimPrefix(toolStr, "function:"))
+			continue
+		}
+
+		if toolStr != "code_interpreter" && toolStr != "file_search" {
+			errMsg := fmt.Sprintf("invalid tool: '%s'. (should be 'code_interpreter' | 'file_search' | 'function:<file>')", toolStr)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+
+		tools = addTool(tools, toolStr)
+	}
+
+	if len(*c.vectorStoreArg) > 0 {
+		tools = addTool(tools, "file_search")
+	}
+
+	if len(*c.codeFileArg) > 0 {
+		tools = addTool(tools, "code_interpreter")
+	}
+
+	for _, toolFile := range toolFiles {
+		tool, err := schema.ToolInput(toolFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tools = append(tools, *tool)
+	}
+
+	return tools, nil
+}
+
+func (c *CreateCommand) getToolResources() map[string]map[string][]string {
+	toolResources := make(map[string]map[string][]string)
+
+	if len(*c.vectorStoreArg) > 0 {
+		toolResources["file_search"] = map[string][]string{"vector_store_ids": *c.vectorStoreArg}
+	}
+
+	if len(*c.codeFileArg) > 0 {
+		toolResources["code_interpreter"] = map[string][]string{"file_ids": *c.codeFileArg}
+	}
+
+	if len(toolResources) < 1 {
+		return nil
+	}
+
+	return toolResources
+}
+
+func (c *CreateCommand) getResFormat() (*openai.ResponseFormat, error) {
+	if *c.jsonSchemaArg != "" {
+		jsonSchema, err := schema.JSONSchemaInput(*c.jsonSchemaArg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &openai.ResponseFormat{Type: "json_schema", JSONSchema: jsonSchema}, nil
+	}
+
+	if *c.resForm != "auto" && *c.resForm != "text" && *c.resForm != "json_object" {
+		errMsg := fmt.Sprintf("invalid response format: '%s'. (should be 'auto' | 'text' | 'json_object', or use --json-schema)", *c.resForm)
+		err := errors.New(errMsg)
+		return nil, err
+	}
+
+	return &openai.ResponseFormat{Type: *c.resForm}, nil
+}
+
+func addTool(tools []openai.Tool, toolType string) []openai.Tool {
+	for _, tool := range tools {
+		if tool.Type == toolType {
+			return tools
+		}
+	}
+
+	return append(tools, openai.Tool{Type: toolType})
+}
+
+func (c *CreateCommand) getCreatedAsstOutput(args *[]argparse.Arg, createdAssts []*openai.CreatedAssistant) (*[]byte, error) {
+	if len(createdAssts) == 1 {
+		asstsOutput, err := io.ObjToJSON(createdAssts[0])
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &asstsOutput, nil
+	}
+
+	asstsOutput, err := io.ListToJSON(&createdAssts)
 
 	if err != nil {
 		return nil, err
@@ -4,9 +4,13 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/jackitaliano/oait/internal/audit"
+	"github.com/jackitaliano/oait/internal/backup"
 	"github.com/jackitaliano/oait/internal/filter"
 	"github.com/jackitaliano/oait/internal/io"
 	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/plan"
+	"github.com/jackitaliano/oait/internal/protect"
 	"github.com/jackitaliano/oait/internal/tui"
 
 	"github.com/akamensky/argparse"
@@ -26,6 +30,14 @@ type DelCommand struct {
 	timeGTArg          *float64
 	nameContainsArg    *[]string
 	nameNotContainsArg *[]string
+	yesFlag            *bool
+	noVerifyFlag       *bool
+	dryRunFlag         *bool
+	applyPlanArg       *string
+	backupArg          *string
+	noBackupFlag       *bool
+	forceProtectedFlag *bool
+	maxDeleteArg       *int
 }
 
 func NewDelCommand(command *argparse.Command) *DelCommand {
@@ -43,6 +55,14 @@ func NewDelCommand(command *argparse.Command) *DelCommand {
 	timeGTArg := subCommand.Float("D", "Days", &argparse.Options{Required: false, Help: "Filter by GT days"})
 	nameContainsArg := subCommand.StringList("n", "name", &argparse.Options{Required: false, Help: "Filter by Asst containing name"})
 	nameNotContainsArg := subCommand.StringList("N", "Name", &argparse.Options{Required: false, Help: "Filter by Asst not containing name"})
+	yesFlag := subCommand.Flag("y", "yes", &argparse.Options{Required: false, Help: "Answer yes to all prompts"})
+	noVerifyFlag := subCommand.Flag("", "no-verify", &argparse.Options{Required: false, Help: "Skip verification prompt"})
+	dryRunFlag := subCommand.Flag("", "dry-run", &argparse.Options{Required: false, Help: "Output deletion plan without deleting"})
+	applyPlanArg := subCommand.String("", "apply-plan", &argparse.Options{Required: false, Help: "Delete exactly the assts in plan file (from --dry-run)"})
+	backupArg := subCommand.String("", "backup", &argparse.Options{Required: false, Help: "Backup directory for assts snapshots before deletion (default: <config dir>/oait/backups)"})
+	noBackupFlag := subCommand.Flag("", "no-backup", &argparse.Options{Required: false, Help: "Skip backup before deletion"})
+	forceProtectedFlag := subCommand.Flag("", "force-protected", &argparse.Options{Required: false, Help: "Include assts matched by the protection policy"})
+	maxDeleteArg := subCommand.Int("", "max-delete", &argparse.Options{Required: false, Help: "Abort if more than N assts would be deleted"})
 
 	return &DelCommand{
 		name,
@@ -57,6 +77,14 @@ func NewDelCommand(command *argparse.Command) *DelCommand {
 		timeGTArg,
 		nameContainsArg,
 		nameNotContainsArg,
+		yesFlag,
+		noVerifyFlag,
+		dryRunFlag,
+		applyPlanArg,
+		backupArg,
+		noBackupFlag,
+		forceProtectedFlag,
+		maxDeleteArg,
 	}
 }
 
@@ -68,6 +96,11 @@ func (d *DelCommand) Happened() bool {
 func (d *DelCommand) Run(key string) error {
 	args := d.command.GetArgs()
 	allParsed := args[3].GetParsed()
+	applyPlanParsed := args[13].GetParsed()
+
+	if applyPlanParsed {
+		return d.applyPlan(key)
+	}
 
 	var asstObjects *[]openai.AsstObject
 	var err error
@@ -107,9 +140,21 @@ func (d *DelCommand) Run(key string) error {
 	}
 	fmt.Printf("✓\n")
 
-	deleteAsstIDs := getAsstIDsFromObjects(filteredAsstObjects)
+	deleteAsstIDs, err := protect.Filter(key, "assts", getAsstIDsFromObjects(filteredAsstObjects), *d.orgArg, *d.forceProtectedFlag)
+
+	if err != nil {
+		return err
+	}
 
-	verify := verifyBeforeDelete()
+	if *d.dryRunFlag {
+		return d.outputPlan(&args, filteredAsstObjects, deleteAsstIDs)
+	}
+
+	verify, err := verifyBeforeDelete(*d.noVerifyFlag, *d.yesFlag)
+
+	if err != nil {
+		return err
+	}
 
 	if verify {
 		fmt.Printf("Formatting assts output...\t")
@@ -129,9 +174,82 @@ func (d *DelCommand) Run(key string) error {
 		}
 	}
 
-	confirmed := confirmDelete()
+	return d.deleteAssts(key, deleteAsstIDs)
+}
+
+func (d *DelCommand) applyPlan(key string) error {
+	fmt.Printf("Reading plan...\t\t\t")
+	deletePlan, err := io.JSONInput[plan.Plan](*d.applyPlanArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+
+	deleteAsstIDs, err := deletePlan.GetIDs("assts")
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	if *d.orgArg == "" { // Delete from the org the plan was made in
+		*d.orgArg = deletePlan.OrgID
+	}
+
+	deleteAsstIDs, err = protect.Filter(key, "assts", deleteAsstIDs, *d.orgArg, *d.forceProtectedFlag)
+
+	if err != nil {
+		return err
+	}
+
+	verify, err := verifyBeforeDelete(*d.noVerifyFlag, *d.yesFlag)
+
+	if err != nil {
+		return err
+	}
+
+	if verify {
+		planOutput, err := io.ObjToJSON(deletePlan)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Outputting plan... \n\n")
+		fmt.Printf("%v\n", string(planOutput))
+	}
+
+	return d.deleteAssts(key, deleteAsstIDs)
+}
+
+func (d *DelCommand) deleteAssts(key string, deleteAsstIDs []string) error {
+	maxDeleteParsed := d.command.GetArgs()[17].GetParsed()
+
+	if maxDeleteParsed && len(deleteAsstIDs) > *d.maxDeleteArg {
+		errMsg := fmt.Sprintf("Refusing to delete %v assts: more than --max-delete %v", len(deleteAsstIDs), *d.maxDeleteArg)
+		err := errors.New(errMsg)
+		return err
+	}
+
+	confirmed, err := confirmDelete(*d.yesFlag)
+
+	if err != nil {
+		return err
+	}
 
 	if confirmed {
+		if !*d.noBackupFlag {
+			err = backup.Before(key, "assts", deleteAsstIDs, *d.orgArg, *d.backupArg)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		audit.LogConfirmed("assts del", *d.orgArg, deleteAsstIDs, *d.yesFlag)
+
 		fmt.Printf("Deleting assts...\t\t")
 		numDeleted := openai.DeleteAssts(key, deleteAsstIDs, *d.orgArg)
 		fmt.Printf("✓\n")
@@ -143,12 +261,63 @@ func (d *DelCommand) Run(key string) error {
 	return nil
 }
 
-func verifyBeforeDelete() bool {
-	return tui.YesNoLoop("Verify assistants before deletion?")
+func (d *DelCommand) outputPlan(args *[]argparse.Arg, filteredAsstObjects *[]openai.AsstObject, deleteAsstIDs []string) error {
+	fmt.Printf("Formatting plan output...\t")
+	items := plan.KeepIDs(plan.AsstItems(filteredAsstObjects, d.getReasons(args)), deleteAsstIDs)
+	deletePlan := plan.NewDeletePlan("assts", *d.orgArg, items)
+
+	planOutput, err := io.ObjToJSON(deletePlan)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Outputting plan (dry run, %v assts)... \n\n", len(items))
+	return d.outputAssts(args, &planOutput)
+}
+
+func (d *DelCommand) getReasons(args *[]argparse.Arg) []string {
+	reasons := []string{}
+
+	if (*args)[3].GetParsed() && *d.allFlag {
+		reasons = append(reasons, "all assts")
+	} else if (*args)[1].GetParsed() {
+		reasons = append(reasons, "listed in --ids")
+	} else if (*args)[2].GetParsed() {
+		reasons = append(reasons, fmt.Sprintf("listed in '%v'", *d.inputArg))
+	}
+
+	if (*args)[6].GetParsed() {
+		reasons = append(reasons, fmt.Sprintf("created <= %v days ago", *d.timeLTEArg))
+	}
+
+	if (*args)[7].GetParsed() {
+		reasons = append(reasons, fmt.Sprintf("created > %v days ago", *d.timeGTArg))
+	}
+
+	for _, name := range *d.nameContainsArg {
+		reasons = append(reasons, fmt.Sprintf("name contains '%v'", name))
+	}
+
+	for _, name := range *d.nameNotContainsArg {
+		reasons = append(reasons, fmt.Sprintf("name not contains '%v'", name))
+	}
+
+	return reasons
+}
+
+func verifyBeforeDelete(noVerify bool, assumeYes bool) (bool, error) {
+	if noVerify {
+		return false, nil
+	}
+
+	return tui.Confirm("Verify assistants before deletion?", assumeYes)
 }
 
-func confirmDelete() bool {
-	return tui.YesNoLoop("Confirm deletion")
+func confirmDelete(assumeYes bool) (bool, error) {
+	return tui.Confirm("Confirm deletion", assumeYes)
 }
 
 func (d *DelCommand) getAsstIDs(args *[]argparse.Arg) ([]string, error) {
@@ -0,0 +1,131 @@
+package assts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/diff"
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/spec"
+
+	"github.com/akamensky/argparse"
+)
+
+type DiffCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	asstArg  *string
+	otherArg *string
+	specArg  *string
+	orgArg   *string
+}
+
+func NewDiffCommand(command *argparse.Command) *DiffCommand {
+	const name = "diff"
+	const desc = "Diff Assistants or an Assistant and Spec"
+
+	subCommand := command.NewCommand(name, desc)
+
+	asstArg := subCommand.StringPositional(&argparse.Options{Help: "Asst ID to diff from"})
+	otherArg := subCommand.StringPositional(&argparse.Options{Help: "Asst ID to diff against (leave out with --spec)"})
+	specArg := subCommand.String("s", "spec", &argparse.Options{Required: false, Help: "Spec file to diff the asst against"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+
+	return &DiffCommand{
+		name,
+		desc,
+		subCommand,
+		asstArg,
+		otherArg,
+		specArg,
+		orgArg,
+	}
+}
+
+func (d *DiffCommand) Happened() bool {
+
+	return d.command.Happened()
+}
+
+func (d *DiffCommand) Run(key string) error {
+	args := d.command.GetArgs()
+	specParsed := args[3].GetParsed()
+
+	asstIDs := []string{}
+	for _, asstID := range []string{*d.asstArg, *d.otherArg} {
+		if asstID != "" {
+			asstIDs = append(asstIDs, asstID)
+		}
+	}
+
+	if specParsed && len(asstIDs) != 1 {
+		errMsg := fmt.Sprintf("Expected 1 asst id with --spec, got %v", len(asstIDs))
+		err := errors.New(errMsg)
+		return err
+	}
+
+	if !specParsed && len(asstIDs) != 2 {
+		errMsg := fmt.Sprintf("Expected 2 asst ids to diff, got %v", len(asstIDs))
+		err := errors.New(errMsg)
+		return err
+	}
+
+	fmt.Printf("Retrieving assts...\t\t")
+	asstObjects := make([]*openai.AsstObject, len(asstIDs))
+
+	for i, asstID := range asstIDs {
+		var err error
+		asstObjects[i], err = openai.GetAsstObject(key, asstID, *d.orgArg)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+	}
+	fmt.Printf("✓\n")
+
+	a := asstObjects[0]
+	aLabel := a.ID
+	var b *openai.AsstObject
+	var bLabel string
+
+	if specParsed {
+		fmt.Printf("Reading spec...\t\t\t")
+		specs, err := spec.Load(*d.specArg)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+
+		if len(specs) != 1 {
+			fmt.Printf("X\n")
+			errMsg := fmt.Sprintf("Expected a single spec file, found %v specs in '%v'", len(specs), *d.specArg)
+			err := errors.New(errMsg)
+			return err
+		}
+		fmt.Printf("✓\n")
+
+		b, _ = specs[0].Patch(*a)
+		bLabel = specs[0].Source
+	} else {
+		b = asstObjects[1]
+		bLabel = b.ID
+	}
+
+	changes := diff.Assistants(a, b)
+
+	if len(changes) < 1 {
+		fmt.Printf("No differences between %v and %v.\n", aLabel, bLabel)
+		return nil
+	}
+
+	fmt.Printf("Outputting diff... \n\n")
+	fmt.Printf("--- %v\n+++ %v\n", aLabel, bLabel)
+	fmt.Printf("%v\n", strings.Join(changes, "\n"))
+
+	return nil
+}
@@ -0,0 +1,201 @@
+package assts
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/filter"
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/spec"
+
+	"github.com/akamensky/argparse"
+)
+
+type ExportCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	asstsArg           *[]string
+	inputArg           *string
+	allFlag            *bool
+	orgArg             *string
+	outputArg          *string
+	timeLTEArg         *float64
+	timeGTArg          *float64
+	nameContainsArg    *[]string
+	nameNotContainsArg *[]string
+}
+
+func NewExportCommand(command *argparse.Command) *ExportCommand {
+	const name = "export"
+	const desc = "Export Assistants to Specs"
+
+	subCommand := command.NewCommand(name, desc)
+
+	asstsArg := subCommand.StringList("i", "ids", &argparse.Options{Required: false, Help: "List of Asst IDs"})
+	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: false, Help: "Asst File Input (of ids)"})
+	allFlag := subCommand.Flag("A", "all", &argparse.Options{Required: false, Help: "Export all assts"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: true, Help: "Spec Output Directory"})
+	timeLTEArg := subCommand.Float("d", "days", &argparse.Options{Required: false, Help: "Filter by LTE to days"})
+	timeGTArg := subCommand.Float("D", "Days", &argparse.Options{Required: false, Help: "Filter by GT days"})
+	nameContainsArg := subCommand.StringList("n", "name", &argparse.Options{Required: false, Help: "Filter by Asst containing name"})
+	nameNotContainsArg := subCommand.StringList("N", "Name", &argparse.Options{Required: false, Help: "Filter by Asst not containing name"})
+
+	return &ExportCommand{
+		name,
+		desc,
+		subCommand,
+		asstsArg,
+		inputArg,
+		allFlag,
+		orgArg,
+		outputArg,
+		timeLTEArg,
+		timeGTArg,
+		nameContainsArg,
+		nameNotContainsArg,
+	}
+}
+
+func (e *ExportCommand) Happened() bool {
+
+	return e.command.Happened()
+}
+
+func (e *ExportCommand) Run(key string) error {
+	args := e.command.GetArgs()
+	allParsed := args[3].GetParsed()
+
+	var asstObjects *[]openai.AsstObject
+	var err error
+
+	if allParsed && *e.allFlag {
+		fmt.Printf("Retrieving all assts...\t\t")
+		asstObjects, err = openai.RetrieveAllAssts(key, *e.orgArg)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+
+		fmt.Printf("✓\n")
+
+	} else {
+
+		fmt.Printf("Retrieving asst ids...\t")
+		asstIDs, err := e.getAsstIDs(&args)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+		fmt.Printf("✓\n")
+
+		fmt.Printf("Retrieving assts...\t\t")
+		asstObjects = openai.RetrieveAssts(key, asstIDs, *e.orgArg)
+		fmt.Printf("✓\n")
+	}
+
+	fmt.Printf("Filtering assts...\t\t")
+	filteredAsstObjects, err := e.filterAssts(&args, asstObjects)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	retrievedAsstObjects := []openai.AsstObject{}
+	for _, asstObject := range *filteredAsstObjects {
+		if asstObject.ID != "" { // Failed to retrieve
+			retrievedAsstObjects = append(retrievedAsstObjects, asstObject)
+		}
+	}
+
+	fmt.Printf("Exporting assts...\t\t")
+	fileNames, err := spec.Export(*e.outputArg, retrievedAsstObjects)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	for _, fileName := range fileNames {
+		fmt.Printf("\t%v\n", fileName)
+	}
+	fmt.Printf("Exported %v assts to '%v'.\n", len(fileNames), *e.outputArg)
+
+	return nil
+}
+
+func (e *ExportCommand) getAsstIDs(args *[]argparse.Arg) ([]string, error) {
+	asstsParsed := (*args)[1].GetParsed()
+	inputParsed := (*args)[2].GetParsed()
+
+	if asstsParsed { // List passed
+		asstIDs, err := io.ListInput(*e.asstsArg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return asstIDs, nil
+
+	}
+
+	if inputParsed { // Asst input passed
+		asstIDs, err := io.FileInput(*e.inputArg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return asstIDs, nil
+	}
+
+	errMsg := fmt.Sprintf("No input options passed to `%v`\n", e.name)
+	err := errors.New(errMsg)
+
+	return nil, err
+}
+
+func (e *ExportCommand) filterAssts(args *[]argparse.Arg, asstObjects *[]openai.AsstObject) (*[]openai.AsstObject, error) {
+	timeLTEParsed := (*args)[6].GetParsed()
+	timeGTParsed := (*args)[7].GetParsed()
+	nameContainsParsed := (*args)[8].GetParsed()
+	nameNotContainsParsed := (*args)[9].GetParsed()
+
+	filtered := asstObjects
+	var err error
+
+	if timeLTEParsed {
+		filtered, err = filter.DaysLTE(filtered, *e.timeLTEArg)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if timeGTParsed {
+		filtered, err = filter.DaysGT(filtered, *e.timeGTArg)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if nameContainsParsed {
+		filtered = filter.ContainsName(filtered, *e.nameContainsArg)
+
+	}
+
+	if nameNotContainsParsed {
+		filtered = filter.NotContainsName(filtered, *e.nameNotContainsArg)
+	}
+
+	return filtered, nil
+}
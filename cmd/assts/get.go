@@ -3,9 +3,11 @@ package assts
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/jackitaliano/oait/internal/filter"
 	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/mirror"
 	"github.com/jackitaliano/oait/internal/openai"
 
 	"github.com/akamensky/argparse"
@@ -25,6 +27,8 @@ type GetCommand struct {
 	timeGTArg          *float64
 	nameContainsArg    *[]string
 	nameNotContainsArg *[]string
+	offlineFlag        *bool
+	includeDeletedFlag *bool
 }
 
 func NewGetCommand(command *argparse.Command) *GetCommand {
@@ -42,6 +46,8 @@ func NewGetCommand(command *argparse.Command) *GetCommand {
 	timeGTArg := subCommand.Float("D", "Days", &argparse.Options{Required: false, Help: "Filter by GT days"})
 	nameContainsArg := subCommand.StringList("n", "name", &argparse.Options{Required: false, Help: "Filter by Asst containing name"})
 	nameNotContainsArg := subCommand.StringList("N", "Name", &argparse.Options{Required: false, Help: "Filter by Asst not containing name"})
+	offlineFlag := subCommand.Flag("", "offline", &argparse.Options{Required: false, Help: "Read assts from the mirror (see `oait sync`)"})
+	includeDeletedFlag := subCommand.Flag("", "include-deleted", &argparse.Options{Required: false, Help: "With --offline and -A, include assts deleted since mirroring"})
 
 	return &GetCommand{
 		name,
@@ -56,6 +62,8 @@ func NewGetCommand(command *argparse.Command) *GetCommand {
 		timeGTArg,
 		nameContainsArg,
 		nameNotContainsArg,
+		offlineFlag,
+		includeDeletedFlag,
 	}
 }
 
@@ -71,7 +79,14 @@ func (g *GetCommand) Run(key string) error {
 	var asstObjects *[]openai.AsstObject
 	var err error
 
-	if allParsed && *g.allFlag {
+	if *g.offlineFlag {
+		asstObjects, err = g.retrieveMirroredAssts(&args)
+
+		if err != nil {
+			return err
+		}
+
+	} else if allParsed && *g.allFlag {
 		fmt.Printf("Retrieving all assts...\t\t")
 		asstObjects, err = openai.RetrieveAllAssts(key, *g.orgArg)
 
@@ -156,6 +171,40 @@ func (g *GetCommand) getAsstIDs(args *[]argparse.Arg) ([]string, error) {
 	return nil, err
 }
 
+func (g *GetCommand) retrieveMirroredAssts(args *[]argparse.Arg) (*[]openai.AsstObject, error) {
+	m, err := mirror.Open(true)
+
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+
+	var asstIDs []string
+
+	if !*g.allFlag {
+		asstIDs, err = g.getAsstIDs(args)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fmt.Printf("Retrieving mirrored assts...\t")
+	asstObjects, missing, err := m.Assts(asstIDs, *g.includeDeletedFlag)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return nil, err
+	}
+	fmt.Printf("✓\n")
+
+	if len(missing) > 0 {
+		fmt.Printf("WARNING: %v assts not in the mirror (see `oait sync`): %v\n", len(missing), strings.Join(missing, " "))
+	}
+
+	return asstObjects, nil
+}
+
 func (g *GetCommand) filterAssts(args *[]argparse.Arg, asstObjects *[]openai.AsstObject) (*[]openai.AsstObject, error) {
 	timeLTEParsed := (*args)[6].GetParsed()
 	timeGTParsed := (*args)[7].GetParsed()
@@ -0,0 +1,114 @@
+package assts
+
+import (
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/audit"
+	"github.com/jackitaliano/oait/internal/backup"
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/tui"
+
+	"github.com/akamensky/argparse"
+)
+
+type RestoreCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	inputArg  *string
+	orgArg    *string
+	outputArg *string
+	yesFlag   *bool
+}
+
+func NewRestoreCommand(command *argparse.Command) *RestoreCommand {
+	const name = "restore"
+	const desc = "Restore Assistants from backup"
+
+	subCommand := command.NewCommand(name, desc)
+
+	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: true, Help: "Backup directory or snapshot file"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "ID mapping File Output"})
+	yesFlag := subCommand.Flag("y", "yes", &argparse.Options{Required: false, Help: "Answer yes to all prompts"})
+
+	return &RestoreCommand{
+		name,
+		desc,
+		subCommand,
+		inputArg,
+		orgArg,
+		outputArg,
+		yesFlag,
+	}
+}
+
+func (r *RestoreCommand) Happened() bool {
+	return r.command.Happened()
+}
+
+func (r *RestoreCommand) Run(key string) error {
+	args := r.command.GetArgs()
+
+	fmt.Printf("Reading backup...\t\t")
+	snapshots, err := backup.Read(*r.inputArg, "assts")
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	confirmMsg := fmt.Sprintf("Confirm restore of %v assistants", len(snapshots))
+	confirmed, err := tui.Confirm(confirmMsg, *r.yesFlag)
+
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		fmt.Printf("Cancelled.\n")
+		return nil
+	}
+
+	fmt.Printf("Restoring assts...\t\t")
+	restored := backup.RestoreAssts(key, snapshots, *r.orgArg)
+	fmt.Printf("✓\n")
+
+	restoredIDs := []string{}
+	for _, res := range restored {
+		if res.NewID != "" {
+			restoredIDs = append(restoredIDs, res.NewID)
+		}
+	}
+
+	audit.LogConfirmed("assts restore", *r.orgArg, restoredIDs, *r.yesFlag)
+	fmt.Printf("Restored %v/%v assts.\n", len(restoredIDs), len(snapshots))
+
+	mappingOutput, err := io.ObjToJSON(&restored)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Outputting ID mapping... \n\n")
+	return r.outputMapping(&args, &mappingOutput)
+}
+
+func (r *RestoreCommand) outputMapping(args *[]argparse.Arg, output *[]byte) error {
+	outputParsed := (*args)[3].GetParsed()
+
+	if outputParsed {
+		err := io.FileOutput(*r.outputArg, output)
+
+		if err != nil {
+			return err
+		}
+
+	} else {
+		fmt.Printf("%v\n", string(*output))
+	}
+
+	return nil
+}
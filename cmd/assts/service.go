@@ -4,8 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/akamensky/argparse"
+
+	"github.com/jackitaliano/oait/internal/models"
 )
 
 type AsstsService struct {
@@ -13,9 +17,15 @@ type AsstsService struct {
 	desc    string
 	command *argparse.Command
 
-	getCommand    *GetCommand
-	delCommand    *DelCommand
-	createCommand *CreateCommand
+	getCommand     *GetCommand
+	delCommand     *DelCommand
+	createCommand  *CreateCommand
+	restoreCommand *RestoreCommand
+	updateCommand  *UpdateCommand
+	applyCommand   *ApplyCommand
+	exportCommand  *ExportCommand
+	diffCommand    *DiffCommand
+	cloneCommand   *CloneCommand
 }
 
 func NewService(parser *argparse.Parser) *AsstsService {
@@ -27,6 +37,12 @@ func NewService(parser *argparse.Parser) *AsstsService {
 	get := NewGetCommand(service)
 	del := NewDelCommand(service)
 	create := NewCreateCommand(service)
+	restore := NewRestoreCommand(service)
+	update := NewUpdateCommand(service)
+	apply := NewApplyCommand(service)
+	export := NewExportCommand(service)
+	diff := NewDiffCommand(service)
+	clone := NewCloneCommand(service)
 
 	return &AsstsService{
 		name,
@@ -35,6 +51,12 @@ func NewService(parser *argparse.Parser) *AsstsService {
 		get,
 		del,
 		create,
+		restore,
+		update,
+		apply,
+		export,
+		diff,
+		clone,
 	}
 }
 
@@ -64,6 +86,54 @@ func (a *AsstsService) Run(key string) error {
 			os.Exit(1)
 		}
 
+	} else if a.restoreCommand.Happened() {
+		err := a.restoreCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if a.updateCommand.Happened() {
+		err := a.updateCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if a.applyCommand.Happened() {
+		err := a.applyCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if a.exportCommand.Happened() {
+		err := a.exportCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if a.diffCommand.Happened() {
+		err := a.diffCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if a.cloneCommand.Happened() {
+		err := a.cloneCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
 	} else {
 		errMsg := fmt.Sprintf("No command given to `%v`\n", a.name)
 		helpMsg := a.command.Help(errMsg)
@@ -73,3 +143,41 @@ func (a *AsstsService) Run(key string) error {
 
 	return nil
 }
+
+// checkModels warns about model IDs the key and org can't use, or refuses them when
+// strict. If the model list can't be had at all, only strict mode fails.
+func checkModels(key string, orgID string, modelIDs []string, strict bool) error {
+	fmt.Printf("Checking models...\t\t")
+	unknown, staleAge, err := models.Unknown(key, orgID, modelIDs)
+
+	if err != nil {
+		fmt.Printf("X\n")
+
+		if strict {
+			return err
+		}
+
+		fmt.Printf("WARNING: Could not check models. %v\n", err)
+		return nil
+	}
+	fmt.Printf("✓\n")
+
+	if staleAge > 0 {
+		fmt.Printf("WARNING: Failed listing models, checked against cache from %v ago\n", staleAge.Round(time.Minute))
+	}
+
+	if len(unknown) < 1 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("Unknown model: %v (see `oait models list`)", strings.Join(unknown, ", "))
+
+	if strict {
+		err = errors.New(msg)
+		return err
+	}
+
+	fmt.Printf("WARNING: %v\n", msg)
+
+	return nil
+}
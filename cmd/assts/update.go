@@ -0,0 +1,440 @@
+package assts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/audit"
+	"github.com/jackitaliano/oait/internal/diff"
+	"github.com/jackitaliano/oait/internal/filter"
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/tui"
+
+	"github.com/akamensky/argparse"
+)
+
+type UpdateCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	asstsArg           *[]string
+	inputArg           *string
+	allFlag            *bool
+	orgArg             *string
+	timeLTEArg         *float64
+	timeGTArg          *float64
+	nameContainsArg    *[]string
+	nameNotContainsArg *[]string
+	setNameArg         *string
+	setDescArg         *string
+	instructArg        *string
+	instructFileArg    *string
+	modelArg           *string
+	tempArg            *float64
+	topPArg            *float64
+	addToolArg         *[]string
+	removeToolArg      *[]string
+	metadataArg        *[]string
+	yesFlag            *bool
+	strictModelFlag    *bool
+}
+
+func NewUpdateCommand(command *argparse.Command) *UpdateCommand {
+	const name = "update"
+	const desc = "Update Assistants Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	asstsArg := subCommand.StringList("i", "ids", &argparse.Options{Required: false, Help: "List of Asst IDs"})
+	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: false, Help: "Asst File Input (of ids)"})
+	allFlag := subCommand.Flag("A", "all", &argparse.Options{Required: false, Help: "Update all assts"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	timeLTEArg := subCommand.Float("d", "days", &argparse.Options{Required: false, Help: "Filter by LTE to days"})
+	timeGTArg := subCommand.Float("D", "Days", &argparse.Options{Required: false, Help: "Filter by GT days"})
+	nameContainsArg := subCommand.StringList("n", "name", &argparse.Options{Required: false, Help: "Filter by Asst containing name"})
+	nameNotContainsArg := subCommand.StringList("N", "Name", &argparse.Options{Required: false, Help: "Filter by Asst not containing name"})
+	setNameArg := subCommand.String("", "set-name", &argparse.Options{Required: false, Help: "New name of assistant"})
+	setDescArg := subCommand.String("", "desc", &argparse.Options{Required: false, Help: "New description of assistant"})
+	instructArg := subCommand.String("", "instruct", &argparse.Options{Required: false, Help: "New instructions for assistant"})
+	instructFileArg := subCommand.String("", "instructions-file", &argparse.Options{Required: false, Help: "Read new instructions for assistant from file"})
+	modelArg := subCommand.String("m", "model", &argparse.Options{Required: false, Help: "New OpenAI Model for assistant"})
+	tempArg := subCommand.Float("t", "temp", &argparse.Options{Required: false, Help: "New temperature of assistant <0.0 - 2.0>"})
+	topPArg := subCommand.Float("T", "topp", &argparse.Options{Required: false, Help: "New top P of assistant <0.0 - 1.0>"})
+	addToolArg := subCommand.StringList("", "add-tool", &argparse.Options{Required: false, Help: "Add tool <code_interpreter | file_search>"})
+	removeToolArg := subCommand.StringList("", "remove-tool", &argparse.Options{Required: false, Help: "Remove tool <type | function:<name>>"})
+	metadataArg := subCommand.StringList("", "meta", &argparse.Options{Required: false, Help: "Set metadata <key>=<value> (empty value removes key)"})
+	yesFlag := subCommand.Flag("y", "yes", &argparse.Options{Required: false, Help: "Answer yes to all prompts"})
+	strictModelFlag := subCommand.Flag("", "strict-model", &argparse.Options{Required: false, Help: "Refuse unknown model IDs instead of warning"})
+
+	return &UpdateCommand{
+		name,
+		desc,
+		subCommand,
+		asstsArg,
+		inputArg,
+		allFlag,
+		orgArg,
+		timeLTEArg,
+		timeGTArg,
+		nameContainsArg,
+		nameNotContainsArg,
+		setNameArg,
+		setDescArg,
+		instructArg,
+		instructFileArg,
+		modelArg,
+		tempArg,
+		topPArg,
+		addToolArg,
+		removeToolArg,
+		metadataArg,
+		yesFlag,
+		strictModelFlag,
+	}
+}
+
+func (u *UpdateCommand) Happened() bool {
+
+	return u.command.Happened()
+}
+
+func (u *UpdateCommand) Run(key string) error {
+	args := u.command.GetArgs()
+	allParsed := args[3].GetParsed()
+	modelParsed := args[13].GetParsed()
+
+	if modelParsed {
+		err := checkModels(key, *u.orgArg, []string{*u.modelArg}, *u.strictModelFlag)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	var asstObjects *[]openai.AsstObject
+	var err error
+
+	if allParsed && *u.allFlag {
+		fmt.Printf("Retrieving all assts...\t\t")
+		asstObjects, err = openai.RetrieveAllAssts(key, *u.orgArg)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+
+		fmt.Printf("✓\n")
+
+	} else {
+
+		fmt.Printf("Retrieving asst ids...\t")
+		asstIDs, err := u.getAsstIDs(&args)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+		fmt.Printf("✓\n")
+
+		fmt.Printf("Retrieving assts...\t\t")
+		asstObjects = openai.RetrieveAssts(key, asstIDs, *u.orgArg)
+		fmt.Printf("✓\n")
+	}
+
+	fmt.Printf("Filtering assts...\t\t")
+	filteredAsstObjects, err := u.filterAssts(&args, asstObjects)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Patching assts...\t\t")
+	modifiedAssts := make(map[string]*openai.ModifiedAssistant)
+	changes := []string{}
+
+	for _, asstObject := range *filteredAsstObjects {
+		if asstObject.ID == "" { // Failed to retrieve
+			continue
+		}
+
+		patchedAsst, modifiedAsst, err := u.patchAsst(&args, asstObject)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+
+		asstChanges := diff.Assistants(&asstObject, patchedAsst)
+
+		if len(asstChanges) < 1 {
+			continue
+		}
+
+		modifiedAssts[asstObject.ID] = modifiedAsst
+		changes = append(changes, fmt.Sprintf("%v (%v)", asstObject.ID, asstObject.Name))
+
+		for _, change := range asstChanges {
+			changes = append(changes, "  "+change)
+		}
+	}
+	fmt.Printf("✓\n")
+
+	if len(modifiedAssts) < 1 {
+		fmt.Printf("No changes to %v assts.\n", len(*filteredAsstObjects))
+		return nil
+	}
+
+	fmt.Printf("Outputting changes... \n\n")
+	fmt.Printf("%v\n\n", strings.Join(changes, "\n"))
+
+	confirmMsg := fmt.Sprintf("Confirm update of %v assts", len(modifiedAssts))
+	confirmed, err := tui.Confirm(confirmMsg, *u.yesFlag)
+
+	if err != nil {
+		return err
+	}
+
+	if confirmed {
+		updateAsstIDs := make([]string, 0, len(modifiedAssts))
+		for asstID := range modifiedAssts {
+			updateAsstIDs = append(updateAsstIDs, asstID)
+		}
+
+		audit.LogConfirmed("assts update", *u.orgArg, updateAsstIDs, *u.yesFlag)
+
+		fmt.Printf("Updating assts...\t\t")
+		numUpdated := openai.UpdateAssts(key, modifiedAssts, *u.orgArg)
+		fmt.Printf("✓\n")
+		fmt.Printf("Updated %v assts.\n", numUpdated)
+	} else {
+		fmt.Printf("Cancelled.\n")
+	}
+
+	return nil
+}
+
+// patchAsst applies the update flags to asstObject, returning the patched assistant
+// (for diffing) and the fields to send.
+func (u *UpdateCommand) patchAsst(args *[]argparse.Arg, asstObject openai.AsstObject) (*openai.AsstObject, *openai.ModifiedAssistant, error) {
+	setNameParsed := (*args)[9].GetParsed()
+	setDescParsed := (*args)[10].GetParsed()
+	instructParsed := (*args)[11].GetParsed()
+	instructFileParsed := (*args)[12].GetParsed()
+	modelParsed := (*args)[13].GetParsed()
+	tempParsed := (*args)[14].GetParsed()
+	topPParsed := (*args)[15].GetParsed()
+	addToolParsed := (*args)[16].GetParsed()
+	removeToolParsed := (*args)[17].GetParsed()
+	metadataParsed := (*args)[18].GetParsed()
+
+	patched := asstObject
+	modified := openai.ModifiedAssistant{}
+
+	if setNameParsed {
+		patched.Name = *u.setNameArg
+		modified.Name = u.setNameArg
+	}
+
+	if setDescParsed {
+		patched.Description = *u.setDescArg
+		modified.Description = u.setDescArg
+	}
+
+	if instructParsed {
+		patched.Instructions = *u.instructArg
+		modified.Instructions = u.instructArg
+	}
+
+	if instructFileParsed {
+		instructions, err := io.TextInput(*u.instructFileArg)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		patched.Instructions = instructions
+		modified.Instructions = &instructions
+	}
+
+	if modelParsed {
+		patched.Model = *u.modelArg
+		modified.Model = u.modelArg
+	}
+
+	if tempParsed {
+		patched.Temp = *u.tempArg
+		modified.Temp = u.tempArg
+	}
+
+	if topPParsed {
+		patched.TopP = *u.topPArg
+		modified.TopP = u.topPArg
+	}
+
+	if addToolParsed || removeToolParsed {
+		tools, err := patchTools(asstObject.Tools, *u.addToolArg, *u.removeToolArg)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		patched.Tools = tools
+		modified.Tools = &tools
+	}
+
+	if metadataParsed {
+		metadata, err := patchMetadata(asstObject.Metadata, *u.metadataArg)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		patched.Metadata = metadata
+		modified.Metadata = metadata
+	}
+
+	return &patched, &modified, nil
+}
+
+func patchTools(tools []openai.Tool, addTools []string, removeTools []string) ([]openai.Tool, error) {
+	patched := []openai.Tool{}
+
+	for _, tool := range tools {
+		removed := false
+
+		for _, removeTool := range removeTools {
+			if tool.Type == removeTool || (tool.Function != nil && "function:"+tool.Function.Name == removeTool) {
+				removed = true
+				break
+			}
+		}
+
+		if !removed {
+			patched = append(patched, tool)
+		}
+	}
+
+	for _, addTool := range addTools {
+		if addTool != "code_interpreter" && addTool != "file_search" {
+			errMsg := fmt.Sprintf("invalid tool: '%s'. (should be 'code_interpreter' | 'file_search')", addTool)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+
+		exists := false
+		for _, tool := range patched {
+			if tool.Type == addTool {
+				exists = true
+				break
+			}
+		}
+
+		if !exists {
+			patched = append(patched, openai.Tool{Type: addTool})
+		}
+	}
+
+	return patched, nil
+}
+
+func patchMetadata(metadata map[string]string, metadataStrs []string) (map[string]string, error) {
+	patched := make(map[string]string, len(metadata)+len(metadataStrs))
+
+	for metadataKey, metadataVal := range metadata {
+		patched[metadataKey] = metadataVal
+	}
+
+	for _, metadataStr := range metadataStrs {
+		metadataSplit := strings.SplitN(metadataStr, "=", 2)
+
+		if len(metadataSplit) < 2 {
+			errMsg := fmt.Sprintf("invalid metadata: '%s'. (should be '<key>=<value>')", metadataStr)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+
+		metadataKey := metadataSplit[0]
+		metadataVal := metadataSplit[1]
+
+		if metadataVal == "" {
+			delete(patched, metadataKey)
+		} else {
+			patched[metadataKey] = metadataVal
+		}
+	}
+
+	return patched, nil
+}
+
+func (u *UpdateCommand) getAsstIDs(args *[]argparse.Arg) ([]string, error) {
+	asstsParsed := (*args)[1].GetParsed()
+	inputParsed := (*args)[2].GetParsed()
+
+	if asstsParsed { // List passed
+		asstIDs, err := io.ListInput(*u.asstsArg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return asstIDs, nil
+
+	}
+
+	if inputParsed { // Asst input passed
+		asstIDs, err := io.FileInput(*u.inputArg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return asstIDs, nil
+	}
+
+	errMsg := fmt.Sprintf("No input options passed to `%v`\n", u.name)
+	err := errors.New(errMsg)
+
+	return nil, err
+}
+
+func (u *UpdateCommand) filterAssts(args *[]argparse.Arg, asstObjects *[]openai.AsstObject) (*[]openai.AsstObject, error) {
+	timeLTEParsed := (*args)[5].GetParsed()
+	timeGTParsed := (*args)[6].GetParsed()
+	nameContainsParsed := (*args)[7].GetParsed()
+	nameNotContainsParsed := (*args)[8].GetParsed()
+
+	filtered := asstObjects
+	var err error
+
+	if timeLTEParsed {
+		filtered, err = filter.DaysLTE(filtered, *u.timeLTEArg)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if timeGTParsed {
+		filtered, err = filter.DaysGT(filtered, *u.timeGTArg)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if nameContainsParsed {
+		filtered = filter.ContainsName(filtered, *u.nameContainsArg)
+
+	}
+
+	if nameNotContainsParsed {
+		filtered = filter.NotContainsName(filtered, *u.nameNotContainsArg)
+	}
+
+	return filtered, nil
+}
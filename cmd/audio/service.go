@@ -0,0 +1,231 @@
+package audio
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/akamensky/argparse"
+
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/pool"
+)
+
+var audioExts = []string{".flac", ".m4a", ".mp3", ".mp4", ".mpeg", ".mpga", ".ogg", ".wav", ".webm"}
+
+var formatExts = map[string]string{
+	"json":         ".json",
+	"verbose_json": ".json",
+	"srt":          ".srt",
+	"vtt":          ".vtt",
+	"text":         ".txt",
+}
+
+type AudioService struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	transcribeCommand *TranscribeCommand
+	translateCommand  *TranslateCommand
+	speakCommand      *SpeakCommand
+}
+
+func NewService(parser *argparse.Parser) *AudioService {
+	const name = "audio"
+	const desc = "Audio Tools"
+
+	service := parser.NewCommand(name, desc)
+
+	transcribe := NewTranscribeCommand(service)
+	translate := NewTranslateCommand(service)
+	speak := NewSpeakCommand(service)
+
+	return &AudioService{
+		name,
+		desc,
+		service,
+		transcribe,
+		translate,
+		speak,
+	}
+}
+
+func (a *AudioService) Run(key string) error {
+
+	if a.transcribeCommand.Happened() {
+		err := a.transcribeCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if a.translateCommand.Happened() {
+		err := a.translateCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if a.speakCommand.Happened() {
+		err := a.speakCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else {
+		errMsg := fmt.Sprintf("No command given to `%v`\n", a.name)
+		helpMsg := a.command.Help(errMsg)
+		err := errors.New(helpMsg)
+		return err
+	}
+
+	return nil
+}
+
+type transcribeFunc func(key string, audio openai.FileUpload, transcriptionReq *openai.TranscriptionRequest, orgID string) ([]byte, error)
+
+// transcribeFiles transcribes input (a file, or every audio file in a directory) with at
+// most concurrency requests at once, writing one transcript per input.
+func transcribeFiles(key string, input string, output string, transcriptionReq *openai.TranscriptionRequest, concurrency int, orgID string, transcribe transcribeFunc) error {
+	inputs, outputs, err := batchFiles(input, output, audioExts, formatExts[transcriptionReq.ResponseFormat])
+
+	if err != nil {
+		return err
+	}
+
+	return runBatch("Transcribing", inputs, outputs, concurrency, func(inputFile string) ([]byte, error) {
+		content, err := io.BytesInput(inputFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		audio := openai.FileUpload{Name: filepath.Base(inputFile), Content: content}
+
+		return transcribe(key, audio, transcriptionReq, orgID)
+	})
+}
+
+// runBatch calls process on each input with bounded concurrency and writes each result
+// to the matching output, reporting every failure.
+func runBatch(action string, inputs []string, outputs []string, concurrency int, process func(inputFile string) ([]byte, error)) error {
+	errs := make([]error, len(inputs))
+
+	fmt.Printf("%v %v files...\t\t", action, len(inputs))
+	pool.Run(len(inputs), concurrency, func(i int) {
+		result, err := process(inputs[i])
+
+		if err == nil {
+			err = io.FileOutput(outputs[i], &result)
+		}
+
+		errs[i] = err
+	})
+
+	numFailed := 0
+	for _, err := range errs {
+		if err != nil {
+			numFailed += 1
+		}
+	}
+
+	if numFailed > 0 {
+		fmt.Printf("X\n")
+	} else {
+		fmt.Printf("✓\n")
+	}
+
+	for i, err := range errs {
+		if err != nil {
+			fmt.Printf("\tX %v: %v\n", inputs[i], err)
+		} else {
+			fmt.Printf("\t%v -> %v\n", inputs[i], outputs[i])
+		}
+	}
+
+	if numFailed > 0 {
+		errMsg := fmt.Sprintf("Failed %v of %v files", numFailed, len(inputs))
+		err := errors.New(errMsg)
+		return err
+	}
+
+	return nil
+}
+
+// batchFiles lists the inputs (input itself, or its files with one of exts if it's a
+// directory) and an output for each: <name><outExt> in output, or beside the input.
+func batchFiles(input string, output string, exts []string, outExt string) ([]string, []string, error) {
+	info, err := os.Stat(input)
+
+	if err != nil {
+		err = errors.New("Failed reading input: " + input + ". Error: " + err.Error())
+		return nil, nil, err
+	}
+
+	if !info.IsDir() {
+		if output == "" {
+			output = strings.TrimSuffix(input, filepath.Ext(input)) + outExt
+		}
+
+		return []string{input}, []string{output}, nil
+	}
+
+	entries, err := os.ReadDir(input)
+
+	if err != nil {
+		err = errors.New("Failed reading input: " + input + ". Error: " + err.Error())
+		return nil, nil, err
+	}
+
+	if output == "" {
+		output = input
+	}
+
+	err = os.MkdirAll(output, 0755)
+
+	if err != nil {
+		err = errors.New("Failed to create output directory: " + output + ". Error: " + err.Error())
+		return nil, nil, err
+	}
+
+	inputs := []string{}
+	outputs := []string{}
+
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+
+		if entry.IsDir() || !hasExt(exts, ext) {
+			continue
+		}
+
+		baseName := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		inputs = append(inputs, filepath.Join(input, entry.Name()))
+		outputs = append(outputs, filepath.Join(output, baseName+outExt))
+	}
+
+	if len(inputs) < 1 {
+		errMsg := fmt.Sprintf("No files (%v) found in '%v'", strings.Join(exts, ", "), input)
+		err = errors.New(errMsg)
+		return nil, nil, err
+	}
+
+	return inputs, outputs, nil
+}
+
+func hasExt(exts []string, ext string) bool {
+	for _, e := range exts {
+		if e == ext {
+			return true
+		}
+	}
+
+	return false
+}
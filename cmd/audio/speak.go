@@ -0,0 +1,162 @@
+package audio
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+
+	"github.com/akamensky/argparse"
+)
+
+type SpeakCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	textArg        *string
+	inputArg       *string
+	outputArg      *string
+	modelArg       *string
+	voiceArg       *string
+	formatArg      *string
+	speedArg       *float64
+	concurrencyArg *int
+	orgArg         *string
+}
+
+func NewSpeakCommand(command *argparse.Command) *SpeakCommand {
+	const name = "speak"
+	const desc = "Text to Speech Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	textArg := subCommand.String("p", "text", &argparse.Options{Required: false, Help: "Text to speak"})
+	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: false, Help: "Text file, or directory of .txt/.md files"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Audio File Output (directory for directory input; default speech.<format> or beside input)"})
+	modelArg := subCommand.String("m", "model", &argparse.Options{Required: false, Help: "OpenAI Model", Default: "tts-1"})
+	voiceArg := subCommand.String("v", "voice", &argparse.Options{Required: false, Help: "Voice (e.g. alloy | echo | fable | onyx | nova | shimmer)", Default: "alloy"})
+	formatArg := subCommand.String("", "format", &argparse.Options{Required: false, Help: "Audio format <mp3 | wav | opus> (default from output extension, or mp3)"})
+	speedArg := subCommand.Float("s", "speed", &argparse.Options{Required: false, Help: "Speed <0.25 - 4.0>"})
+	concurrencyArg := subCommand.Int("c", "concurrency", &argparse.Options{Required: false, Help: "Max files spoken at once", Default: 4})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+
+	return &SpeakCommand{
+		name,
+		desc,
+		subCommand,
+		textArg,
+		inputArg,
+		outputArg,
+		modelArg,
+		voiceArg,
+		formatArg,
+		speedArg,
+		concurrencyArg,
+		orgArg,
+	}
+}
+
+func (s *SpeakCommand) Happened() bool {
+
+	return s.command.Happened()
+}
+
+func (s *SpeakCommand) Run(key string) error {
+	args := s.command.GetArgs()
+	textParsed := args[1].GetParsed()
+	inputParsed := args[2].GetParsed()
+	speedParsed := args[7].GetParsed()
+
+	format, err := s.getFormat()
+
+	if err != nil {
+		return err
+	}
+
+	speechReq := openai.SpeechRequest{
+		Model:          *s.modelArg,
+		Voice:          *s.voiceArg,
+		ResponseFormat: format,
+	}
+
+	if speedParsed {
+		speechReq.Speed = s.speedArg
+	}
+
+	if textParsed {
+		output := *s.outputArg
+		if output == "" {
+			output = "speech." + format
+		}
+
+		speechReq.Input = *s.textArg
+
+		fmt.Printf("Speaking text...\t\t")
+		audio, err := openai.Speak(key, &speechReq, *s.orgArg)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+
+		err = io.FileOutput(output, &audio)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+		fmt.Printf("✓\n")
+		fmt.Printf("\t%v\n", output)
+
+		return nil
+	}
+
+	if !inputParsed {
+		errMsg := fmt.Sprintf("No input options passed to `%v`\n", s.name)
+		err := errors.New(errMsg)
+		return err
+	}
+
+	inputs, outputs, err := batchFiles(*s.inputArg, *s.outputArg, []string{".txt", ".md"}, "."+format)
+
+	if err != nil {
+		return err
+	}
+
+	return runBatch("Speaking", inputs, outputs, *s.concurrencyArg, func(inputFile string) ([]byte, error) {
+		text, err := io.TextInput(inputFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		fileSpeechReq := speechReq
+		fileSpeechReq.Input = text
+
+		return openai.Speak(key, &fileSpeechReq, *s.orgArg)
+	})
+}
+
+func (s *SpeakCommand) getFormat() (string, error) {
+	format := *s.formatArg
+
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(*s.outputArg), ".")
+	}
+
+	if format == "" {
+		format = "mp3"
+	}
+
+	if format != "mp3" && format != "wav" && format != "opus" {
+		errMsg := fmt.Sprintf("invalid format: '%s'. (should be 'mp3' | 'wav' | 'opus')", format)
+		err := errors.New(errMsg)
+		return "", err
+	}
+
+	return format, nil
+}
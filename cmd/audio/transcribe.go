@@ -0,0 +1,122 @@
+package audio
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/openai"
+
+	"github.com/akamensky/argparse"
+)
+
+type TranscribeCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	inputArg       *string
+	outputArg      *string
+	modelArg       *string
+	formatArg      *string
+	timestampsArg  *[]string
+	languageArg    *string
+	promptArg      *string
+	tempArg        *float64
+	concurrencyArg *int
+	orgArg         *string
+}
+
+func NewTranscribeCommand(command *argparse.Command) *TranscribeCommand {
+	const name = "transcribe"
+	const desc = "Transcribe Audio Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	inputArg := subCommand.String("i", "input", &argparse.Options{Required: true, Help: "Audio file, or directory of audio files"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Transcript File Output (directory for directory input; default beside input)"})
+	modelArg := subCommand.String("m", "model", &argparse.Options{Required: false, Help: "OpenAI Model", Default: "whisper-1"})
+	formatArg := subCommand.String("", "format", &argparse.Options{Required: false, Help: "Transcript format <json | srt | vtt | text>", Default: "json"})
+	timestampsArg := subCommand.StringList("", "timestamps", &argparse.Options{Required: false, Help: "Timestamp granularity <word | segment> (json format only)"})
+	languageArg := subCommand.String("l", "language", &argparse.Options{Required: false, Help: "Input language (ISO-639-1, e.g. en)"})
+	promptArg := subCommand.String("p", "prompt", &argparse.Options{Required: false, Help: "Prompt to guide style or spellings"})
+	tempArg := subCommand.Float("t", "temp", &argparse.Options{Required: false, Help: "Temperature <0.0 - 1.0>"})
+	concurrencyArg := subCommand.Int("c", "concurrency", &argparse.Options{Required: false, Help: "Max files transcribed at once", Default: 4})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+
+	return &TranscribeCommand{
+		name,
+		desc,
+		subCommand,
+		inputArg,
+		outputArg,
+		modelArg,
+		formatArg,
+		timestampsArg,
+		languageArg,
+		promptArg,
+		tempArg,
+		concurrencyArg,
+		orgArg,
+	}
+}
+
+func (t *TranscribeCommand) Happened() bool {
+
+	return t.command.Happened()
+}
+
+func (t *TranscribeCommand) Run(key string) error {
+	args := t.command.GetArgs()
+	tempParsed := args[8].GetParsed()
+
+	responseFormat, err := getResponseFormat(*t.formatArg, *t.timestampsArg)
+
+	if err != nil {
+		return err
+	}
+
+	transcriptionReq := openai.TranscriptionRequest{
+		Model:                  *t.modelArg,
+		Language:               *t.languageArg,
+		Prompt:                 *t.promptArg,
+		ResponseFormat:         responseFormat,
+		TimestampGranularities: *t.timestampsArg,
+	}
+
+	if tempParsed {
+		transcriptionReq.Temp = t.tempArg
+	}
+
+	return transcribeFiles(key, *t.inputArg, *t.outputArg, &transcriptionReq, *t.concurrencyArg, *t.orgArg, openai.Transcribe)
+}
+
+// getResponseFormat checks the format and timestamps, which need verbose_json.
+func getResponseFormat(format string, timestamps []string) (string, error) {
+	_, ok := formatExts[format]
+
+	if !ok {
+		errMsg := fmt.Sprintf("invalid format: '%s'. (should be 'json' | 'srt' | 'vtt' | 'text')", format)
+		err := errors.New(errMsg)
+		return "", err
+	}
+
+	if len(timestamps) < 1 {
+		return format, nil
+	}
+
+	for _, timestamp := range timestamps {
+		if timestamp != "word" && timestamp != "segment" {
+			errMsg := fmt.Sprintf("invalid timestamps: '%s'. (should be 'word' | 'segment')", timestamp)
+			err := errors.New(errMsg)
+			return "", err
+		}
+	}
+
+	if format != "json" && format != "verbose_json" {
+		errMsg := fmt.Sprintf("--timestamps needs --format json, not '%s'", format)
+		err := errors.New(errMsg)
+		return "", err
+	}
+
+	return "verbose_json", nil
+}
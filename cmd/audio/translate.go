@@ -0,0 +1,80 @@
+package audio
+
+import (
+	"github.com/jackitaliano/oait/internal/openai"
+
+	"github.com/akamensky/argparse"
+)
+
+type TranslateCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	inputArg       *string
+	outputArg      *string
+	modelArg       *string
+	formatArg      *string
+	promptArg      *string
+	tempArg        *float64
+	concurrencyArg *int
+	orgArg         *string
+}
+
+func NewTranslateCommand(command *argparse.Command) *TranslateCommand {
+	const name = "translate"
+	const desc = "Translate Audio to English Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	inputArg := subCommand.String("i", "input", &argparse.Options{Required: true, Help: "Audio file, or directory of audio files"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Translation File Output (directory for directory input; default beside input)"})
+	modelArg := subCommand.String("m", "model", &argparse.Options{Required: false, Help: "OpenAI Model", Default: "whisper-1"})
+	formatArg := subCommand.String("", "format", &argparse.Options{Required: false, Help: "Translation format <json | srt | vtt | text>", Default: "json"})
+	promptArg := subCommand.String("p", "prompt", &argparse.Options{Required: false, Help: "Prompt (in English) to guide style"})
+	tempArg := subCommand.Float("t", "temp", &argparse.Options{Required: false, Help: "Temperature <0.0 - 1.0>"})
+	concurrencyArg := subCommand.Int("c", "concurrency", &argparse.Options{Required: false, Help: "Max files translated at once", Default: 4})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+
+	return &TranslateCommand{
+		name,
+		desc,
+		subCommand,
+		inputArg,
+		outputArg,
+		modelArg,
+		formatArg,
+		promptArg,
+		tempArg,
+		concurrencyArg,
+		orgArg,
+	}
+}
+
+func (t *TranslateCommand) Happened() bool {
+
+	return t.command.Happened()
+}
+
+func (t *TranslateCommand) Run(key string) error {
+	args := t.command.GetArgs()
+	tempParsed := args[6].GetParsed()
+
+	responseFormat, err := getResponseFormat(*t.formatArg, nil)
+
+	if err != nil {
+		return err
+	}
+
+	transcriptionReq := openai.TranscriptionRequest{
+		Model:          *t.modelArg,
+		Prompt:         *t.promptArg,
+		ResponseFormat: responseFormat,
+	}
+
+	if tempParsed {
+		transcriptionReq.Temp = t.tempArg
+	}
+
+	return transcribeFiles(key, *t.inputArg, *t.outputArg, &transcriptionReq, *t.concurrencyArg, *t.orgArg, openai.Translate)
+}
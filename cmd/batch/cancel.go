@@ -0,0 +1,81 @@
+package batch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/audit"
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/tui"
+
+	"github.com/akamensky/argparse"
+)
+
+type CancelCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	batchesArg *[]string
+	inputArg   *string
+	orgArg     *string
+	yesFlag    *bool
+}
+
+func NewCancelCommand(command *argparse.Command) *CancelCommand {
+	const name = "cancel"
+	const desc = "Cancel Batches Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	batchesArg := subCommand.StringList("i", "ids", &argparse.Options{Required: false, Help: "List of Batch IDs"})
+	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: false, Help: "Batch File Input"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	yesFlag := subCommand.Flag("y", "yes", &argparse.Options{Required: false, Help: "Answer yes to all prompts"})
+
+	return &CancelCommand{
+		name,
+		desc,
+		subCommand,
+		batchesArg,
+		inputArg,
+		orgArg,
+		yesFlag,
+	}
+}
+
+func (c *CancelCommand) Happened() bool {
+
+	return c.command.Happened()
+}
+
+func (c *CancelCommand) Run(key string) error {
+	args := c.command.GetArgs()
+
+	batchIDs, err := getBatchIDs(&args, c.batchesArg, c.inputArg, c.name)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Cancelling %v batches:\n\t%v\n", len(batchIDs), strings.Join(batchIDs, "\n\t"))
+	confirmed, err := tui.Confirm("Confirm cancellation", *c.yesFlag)
+
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		fmt.Printf("Cancelled.\n")
+		return nil
+	}
+
+	audit.LogConfirmed("batch cancel", *c.orgArg, batchIDs, *c.yesFlag)
+
+	fmt.Printf("Cancelling batches...\t\t")
+	numCancelled := openai.CancelBatches(key, batchIDs, *c.orgArg)
+	fmt.Printf("✓\n")
+	fmt.Printf("Cancelled %v batches.\n", numCancelled)
+
+	return nil
+}
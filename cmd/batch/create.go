@@ -0,0 +1,187 @@
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/batch"
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+
+	"github.com/akamensky/argparse"
+)
+
+type CreateCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	templateArg *string
+	inputArg    *string
+	endpointArg *string
+	idFieldArg  *string
+	windowArg   *string
+	metadataArg *[]string
+	outputArg   *string
+	dryRunFlag  *bool
+	orgArg      *string
+}
+
+func NewCreateCommand(command *argparse.Command) *CreateCommand {
+	const name = "create"
+	const desc = "Create Batch Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	templateArg := subCommand.String("t", "template", &argparse.Options{Required: true, Help: "JSON request body template, with {{field}} placeholders"})
+	inputArg := subCommand.String("i", "input", &argparse.Options{Required: true, Help: "Dataset, one request per row (.jsonl | .csv)"})
+	endpointArg := subCommand.String("e", "endpoint", &argparse.Options{Required: false, Help: "Endpoint <url path> (e.g. /v1/chat/completions | /v1/embeddings)", Default: "/v1/chat/completions"})
+	idFieldArg := subCommand.String("", "id-field", &argparse.Options{Required: false, Help: "Dataset field to use as custom_id (default row-<n>)"})
+	windowArg := subCommand.String("w", "window", &argparse.Options{Required: false, Help: "Completion window", Default: "24h"})
+	metadataArg := subCommand.StringList("", "meta", &argparse.Options{Required: false, Help: "Set metadata <key>=<value>"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Save the built batch input file (.jsonl)"})
+	dryRunFlag := subCommand.Flag("", "dry-run", &argparse.Options{Required: false, Help: "Build the input file without uploading or creating the batch"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+
+	return &CreateCommand{
+		name,
+		desc,
+		subCommand,
+		templateArg,
+		inputArg,
+		endpointArg,
+		idFieldArg,
+		windowArg,
+		metadataArg,
+		outputArg,
+		dryRunFlag,
+		orgArg,
+	}
+}
+
+func (c *CreateCommand) Happened() bool {
+
+	return c.command.Happened()
+}
+
+func (c *CreateCommand) Run(key string) error {
+	metadata, err := parseMetadata(*c.metadataArg)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Building batch input...\t\t")
+	input, numRequests, err := c.buildInput()
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	if *c.outputArg != "" {
+		err = io.FileOutput(*c.outputArg, &input)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Built %v requests for %v.\n", numRequests, *c.endpointArg)
+
+	if *c.dryRunFlag {
+		if *c.outputArg == "" {
+			fmt.Printf("%v", string(input))
+		}
+
+		return nil
+	}
+
+	fileName := strings.TrimSuffix(filepath.Base(*c.inputArg), filepath.Ext(*c.inputArg)) + "-batch.jsonl"
+
+	fmt.Printf("Uploading batch input...\t")
+	fileObject, err := openai.UploadFile(key, fileName, input, "batch", *c.orgArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	createdBatch := openai.CreatedBatch{
+		InputFileID:      fileObject.ID,
+		Endpoint:         *c.endpointArg,
+		CompletionWindow: *c.windowArg,
+		Metadata:         metadata,
+	}
+
+	fmt.Printf("Creating batch...\t\t")
+	batchObject, err := openai.NewBatch(key, &createdBatch, *c.orgArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+	fmt.Printf("Created batch: %v (input file %v)\n", batchObject.ID, fileObject.ID)
+
+	return nil
+}
+
+func (c *CreateCommand) buildInput() ([]byte, int, error) {
+	template, err := io.BytesInput(*c.templateArg)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := batch.RowsInput(*c.inputArg)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(rows) < 1 {
+		err = errors.New("No rows in input: " + *c.inputArg)
+		return nil, 0, err
+	}
+
+	ids, err := batch.RowIDs(rows, *c.idFieldArg)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	input, err := batch.Build(template, rows, ids, *c.endpointArg)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return input, len(rows), nil
+}
+
+func parseMetadata(metadataStrs []string) (map[string]string, error) {
+	if len(metadataStrs) < 1 {
+		return nil, nil
+	}
+
+	metadata := make(map[string]string, len(metadataStrs))
+
+	for _, metadataStr := range metadataStrs {
+		metadataSplit := strings.SplitN(metadataStr, "=", 2)
+
+		if len(metadataSplit) < 2 {
+			errMsg := fmt.Sprintf("invalid metadata: '%s'. (should be '<key>=<value>')", metadataStr)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+
+		metadata[metadataSplit[0]] = metadataSplit[1]
+	}
+
+	return metadata, nil
+}
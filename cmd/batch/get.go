@@ -0,0 +1,74 @@
+package batch
+
+import (
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+
+	"github.com/akamensky/argparse"
+)
+
+type GetCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	batchesArg *[]string
+	inputArg   *string
+	orgArg     *string
+	outputArg  *string
+}
+
+func NewGetCommand(command *argparse.Command) *GetCommand {
+	const name = "get"
+	const desc = "Get Batches Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	batchesArg := subCommand.StringList("i", "ids", &argparse.Options{Required: false, Help: "List of Batch IDs"})
+	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: false, Help: "Batch File Input"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Batch File Output"})
+
+	return &GetCommand{
+		name,
+		desc,
+		subCommand,
+		batchesArg,
+		inputArg,
+		orgArg,
+		outputArg,
+	}
+}
+
+func (g *GetCommand) Happened() bool {
+
+	return g.command.Happened()
+}
+
+func (g *GetCommand) Run(key string) error {
+	args := g.command.GetArgs()
+
+	fmt.Printf("Retrieving batch ids...\t\t")
+	batchIDs, err := getBatchIDs(&args, g.batchesArg, g.inputArg, g.name)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Retrieving batches...\t\t")
+	batches := openai.RetrieveBatches(key, batchIDs, *g.orgArg)
+	fmt.Printf("✓\n")
+
+	batchesOutput, err := io.ListToJSON(batches)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Outputting batches... \n\n")
+	return outputBatches(&batchesOutput, *g.outputArg)
+}
@@ -0,0 +1,137 @@
+package batch
+
+import (
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/filter"
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+
+	"github.com/akamensky/argparse"
+)
+
+type ListCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	statusArg   *[]string
+	timeLTEArg  *float64
+	timeGTArg   *float64
+	metadataArg *[]string
+	orgArg      *string
+	outputArg   *string
+}
+
+func NewListCommand(command *argparse.Command) *ListCommand {
+	const name = "list"
+	const desc = "List Batches Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	statusArg := subCommand.StringList("s", "status", &argparse.Options{Required: false, Help: "Filter by status (e.g. in_progress | completed | failed)"})
+	timeLTEArg := subCommand.Float("d", "days", &argparse.Options{Required: false, Help: "Filter by LTE to days"})
+	timeGTArg := subCommand.Float("D", "Days", &argparse.Options{Required: false, Help: "Filter by GT days"})
+	metadataArg := subCommand.StringList("", "meta", &argparse.Options{Required: false, Help: "Filter by metadata <key>=<value>"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Batch File Output"})
+
+	return &ListCommand{
+		name,
+		desc,
+		subCommand,
+		statusArg,
+		timeLTEArg,
+		timeGTArg,
+		metadataArg,
+		orgArg,
+		outputArg,
+	}
+}
+
+func (l *ListCommand) Happened() bool {
+
+	return l.command.Happened()
+}
+
+func (l *ListCommand) Run(key string) error {
+	args := l.command.GetArgs()
+
+	fmt.Printf("Retrieving all batches...\t")
+	batches, err := openai.RetrieveAllBatches(key, *l.orgArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Filtering batches...\t\t")
+	filtered, err := l.filterBatches(&args, &batches)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	batchesOutput, err := io.ListToJSON(filtered)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Outputting batches... \n\n")
+	return outputBatches(&batchesOutput, *l.outputArg)
+}
+
+func (l *ListCommand) filterBatches(args *[]argparse.Arg, batches *[]openai.Batch) (*[]openai.Batch, error) {
+	timeLTEParsed := (*args)[2].GetParsed()
+	timeGTParsed := (*args)[3].GetParsed()
+
+	filtered := batches
+	var err error
+
+	if len(*l.statusArg) > 0 {
+		withStatus := []openai.Batch{}
+
+		for _, batch := range *filtered {
+			for _, status := range *l.statusArg {
+				if batch.Status == status {
+					withStatus = append(withStatus, batch)
+					break
+				}
+			}
+		}
+
+		filtered = &withStatus
+	}
+
+	if timeLTEParsed {
+		filtered, err = filter.DaysLTE(filtered, *l.timeLTEArg)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if timeGTParsed {
+		filtered, err = filter.DaysGT(filtered, *l.timeGTArg)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(*l.metadataArg) > 0 {
+		metadata, err := parseMetadata(*l.metadataArg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		filtered = filter.MetadataEquals(filtered, metadata)
+	}
+
+	return filtered, nil
+}
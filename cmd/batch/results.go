@@ -0,0 +1,176 @@
+package batch
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/batch"
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+
+	"github.com/akamensky/argparse"
+)
+
+type ResultsCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	batchArg   *string
+	dataArg    *string
+	idFieldArg *string
+	outputArg  *string
+	orgArg     *string
+}
+
+func NewResultsCommand(command *argparse.Command) *ResultsCommand {
+	const name = "results"
+	const desc = "Batch Results Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	batchArg := subCommand.String("i", "id", &argparse.Options{Required: true, Help: "Batch ID"})
+	dataArg := subCommand.String("", "data", &argparse.Options{Required: false, Help: "Dataset the batch was created from (default join to the request bodies)"})
+	idFieldArg := subCommand.String("", "id-field", &argparse.Options{Required: false, Help: "Dataset field used as custom_id (default row-<n>)"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Results File Output (default <batch id>-results.jsonl)"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+
+	return &ResultsCommand{
+		name,
+		desc,
+		subCommand,
+		batchArg,
+		dataArg,
+		idFieldArg,
+		outputArg,
+		orgArg,
+	}
+}
+
+func (r *ResultsCommand) Happened() bool {
+
+	return r.command.Happened()
+}
+
+func (r *ResultsCommand) Run(key string) error {
+	fmt.Printf("Retrieving batch...\t\t")
+	batchObject, err := openai.GetBatch(key, *r.batchArg, *r.orgArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	if batchObject.OutputFileID == "" && batchObject.ErrorFileID == "" {
+		errMsg := fmt.Sprintf("Batch %v has no results yet (status %v)", batchObject.ID, batchObject.Status)
+		err = errors.New(errMsg)
+		return err
+	}
+
+	fmt.Printf("Reading inputs...\t\t")
+	ids, inputs, err := r.getInputs(key, batchObject)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Downloading results...\t\t")
+	files := [][]byte{}
+
+	for _, fileID := range []string{batchObject.OutputFileID, batchObject.ErrorFileID} {
+		if fileID == "" {
+			continue
+		}
+
+		content, err := openai.GetFileContent(key, fileID, *r.orgArg)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+
+		files = append(files, content)
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Joining results...\t\t")
+	results, summary, err := batch.Join(ids, inputs, files...)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+
+	output, err := batch.ToJSONL(results)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	outputFile := *r.outputArg
+	if outputFile == "" {
+		outputFile = batchObject.ID + "-results.jsonl"
+	}
+
+	err = io.FileOutput(outputFile, &output)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %v results to '%v': %v succeeded, %v failed, %v missing.\n", len(results), outputFile, summary.Succeeded, summary.Failed, summary.Missing)
+
+	return nil
+}
+
+// getInputs returns the custom_ids and the input for each: the dataset row if
+// given, or the request body from the batch input file.
+func (r *ResultsCommand) getInputs(key string, batchObject *openai.Batch) ([]string, []any, error) {
+	if *r.dataArg != "" {
+		rows, err := batch.RowsInput(*r.dataArg)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ids, err := batch.RowIDs(rows, *r.idFieldArg)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		inputs := make([]any, len(rows))
+		for i, row := range rows {
+			inputs[i] = row
+		}
+
+		return ids, inputs, nil
+	}
+
+	content, err := openai.GetFileContent(key, batchObject.InputFileID, *r.orgArg)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requests, err := batch.ParseRequests(content)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]string, len(requests))
+	inputs := make([]any, len(requests))
+
+	for i, request := range requests {
+		ids[i] = request.CustomID
+		inputs[i] = request.Body
+	}
+
+	return ids, inputs, nil
+}
@@ -0,0 +1,138 @@
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/akamensky/argparse"
+
+	"github.com/jackitaliano/oait/internal/io"
+)
+
+type BatchService struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	createCommand  *CreateCommand
+	getCommand     *GetCommand
+	listCommand    *ListCommand
+	cancelCommand  *CancelCommand
+	waitCommand    *WaitCommand
+	resultsCommand *ResultsCommand
+}
+
+func NewService(parser *argparse.Parser) *BatchService {
+	const name = "batch"
+	const desc = "Batch Tools"
+
+	service := parser.NewCommand(name, desc)
+
+	create := NewCreateCommand(service)
+	get := NewGetCommand(service)
+	list := NewListCommand(service)
+	cancel := NewCancelCommand(service)
+	wait := NewWaitCommand(service)
+	results := NewResultsCommand(service)
+
+	return &BatchService{
+		name,
+		desc,
+		service,
+		create,
+		get,
+		list,
+		cancel,
+		wait,
+		results,
+	}
+}
+
+func (b *BatchService) Run(key string) error {
+
+	if b.createCommand.Happened() {
+		err := b.createCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if b.getCommand.Happened() {
+		err := b.getCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if b.listCommand.Happened() {
+		err := b.listCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if b.cancelCommand.Happened() {
+		err := b.cancelCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if b.waitCommand.Happened() {
+		err := b.waitCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if b.resultsCommand.Happened() {
+		err := b.resultsCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else {
+		errMsg := fmt.Sprintf("No command given to `%v`\n", b.name)
+		helpMsg := b.command.Help(errMsg)
+		err := errors.New(helpMsg)
+		return err
+	}
+
+	return nil
+}
+
+func getBatchIDs(args *[]argparse.Arg, idsArg *[]string, inputArg *string, name string) ([]string, error) {
+	idsParsed := (*args)[1].GetParsed()
+	inputParsed := (*args)[2].GetParsed()
+
+	if idsParsed { // List passed
+		return io.ListInput(*idsArg)
+	}
+
+	if inputParsed { // File input passed
+		return io.FileInput(*inputArg)
+	}
+
+	errMsg := fmt.Sprintf("No input options passed to `%v`\n", name)
+	err := errors.New(errMsg)
+
+	return nil, err
+}
+
+func outputBatches(output *[]byte, outputFile string) error {
+	if outputFile != "" {
+		return io.FileOutput(outputFile, output)
+	}
+
+	fmt.Printf("%v\n", string(*output))
+
+	return nil
+}
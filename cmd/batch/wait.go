@@ -0,0 +1,100 @@
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackitaliano/oait/internal/openai"
+
+	"github.com/akamensky/argparse"
+)
+
+type WaitCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	batchArg    *string
+	intervalArg *int
+	timeoutArg  *float64
+	orgArg      *string
+}
+
+func NewWaitCommand(command *argparse.Command) *WaitCommand {
+	const name = "wait"
+	const desc = "Wait for Batch Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	batchArg := subCommand.String("i", "id", &argparse.Options{Required: true, Help: "Batch ID"})
+	intervalArg := subCommand.Int("", "interval", &argparse.Options{Required: false, Help: "Seconds between status checks", Default: 30})
+	timeoutArg := subCommand.Float("", "timeout", &argparse.Options{Required: false, Help: "Give up after this many minutes (default wait until done)"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+
+	return &WaitCommand{
+		name,
+		desc,
+		subCommand,
+		batchArg,
+		intervalArg,
+		timeoutArg,
+		orgArg,
+	}
+}
+
+func (w *WaitCommand) Happened() bool {
+
+	return w.command.Happened()
+}
+
+func (w *WaitCommand) Run(key string) error {
+	if *w.intervalArg < 1 {
+		errMsg := fmt.Sprintf("Invalid interval: %v. (should be at least 1 second)", *w.intervalArg)
+		err := errors.New(errMsg)
+		return err
+	}
+
+	start := time.Now()
+	timeout := time.Duration(*w.timeoutArg * float64(time.Minute))
+	lastStatus := ""
+
+	for {
+		batchObject, err := openai.GetBatch(key, *w.batchArg, *w.orgArg)
+
+		if err != nil {
+			return err
+		}
+
+		counts := batchObject.RequestCounts
+		status := fmt.Sprintf("%v: %v/%v completed, %v failed", batchObject.Status, counts.Completed, counts.Total, counts.Failed)
+
+		if status != lastStatus {
+			fmt.Printf("[%v] %v\n", time.Now().Format(time.TimeOnly), status)
+			lastStatus = status
+		}
+
+		if batchObject.IsDone() {
+			if batchObject.Status != "completed" {
+				errMsg := fmt.Sprintf("Batch %v %v", batchObject.ID, batchObject.Status)
+
+				if batchObject.Errors != nil && len(batchObject.Errors.Data) > 0 {
+					errMsg += ": " + batchObject.Errors.Data[0].Message
+				}
+
+				err = errors.New(errMsg)
+				return err
+			}
+
+			return nil
+		}
+
+		if timeout > 0 && time.Since(start) > timeout {
+			errMsg := fmt.Sprintf("Timed out waiting for batch %v after %v minutes", batchObject.ID, *w.timeoutArg)
+			err = errors.New(errMsg)
+			return err
+		}
+
+		time.Sleep(time.Duration(*w.intervalArg) * time.Second)
+	}
+}
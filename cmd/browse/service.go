@@ -0,0 +1,347 @@
+package browse
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/akamensky/argparse"
+
+	"github.com/jackitaliano/oait/internal/audit"
+	"github.com/jackitaliano/oait/internal/backup"
+	"github.com/jackitaliano/oait/internal/filter"
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/mirror"
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/protect"
+	"github.com/jackitaliano/oait/internal/tui"
+)
+
+type BrowseService struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	threadsArg         *[]string
+	inputArg           *string
+	sessionArg         *string
+	onlyArg            *[]string
+	timeLTEArg         *float64
+	timeGTArg          *float64
+	orgArg             *string
+	offlineFlag        *bool
+	backupArg          *string
+	noBackupFlag       *bool
+	forceProtectedFlag *bool
+}
+
+var kinds = []string{"threads", "files", "assts"}
+
+func NewService(parser *argparse.Parser) *BrowseService {
+	const name = "browse"
+	const desc = "Browse Threads, Files and Assistants Full-Screen"
+
+	service := parser.NewCommand(name, desc)
+
+	threadsArg := service.StringList("i", "ids", &argparse.Options{Required: false, Help: "List of Thread IDs to browse"})
+	inputArg := service.String("f", "file-input", &argparse.Options{Required: false, Help: "Thread File Input"})
+	sessionArg := service.String("s", "session", &argparse.Options{Required: false, Help: "Browse Threads from session-id"})
+	onlyArg := service.StringList("", "only", &argparse.Options{Required: false, Help: "Only browse <threads | files | assts>"})
+	timeLTEArg := service.Float("d", "days", &argparse.Options{Required: false, Help: "Filter by LTE to days"})
+	timeGTArg := service.Float("D", "Days", &argparse.Options{Required: false, Help: "Filter by GT days"})
+	orgArg := service.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	offlineFlag := service.Flag("", "offline", &argparse.Options{Required: false, Help: "Read from the mirror (see `oait sync`); every mirrored thread without ids"})
+	backupArg := service.String("", "backup", &argparse.Options{Required: false, Help: "Backup directory for snapshots before deletion (default: <config dir>/oait/backups)"})
+	noBackupFlag := service.Flag("", "no-backup", &argparse.Options{Required: false, Help: "Skip backup before deletion"})
+	forceProtectedFlag := service.Flag("", "force-protected", &argparse.Options{Required: false, Help: "Delete marked items matched by the protection policy"})
+
+	return &BrowseService{
+		name,
+		desc,
+		service,
+		threadsArg,
+		inputArg,
+		sessionArg,
+		onlyArg,
+		timeLTEArg,
+		timeGTArg,
+		orgArg,
+		offlineFlag,
+		backupArg,
+		noBackupFlag,
+		forceProtectedFlag,
+	}
+}
+
+func (b *BrowseService) Run(key string) error {
+	err := b.browse(key)
+
+	if err != nil {
+		fmt.Printf("ERROR: %v\n", err.Error())
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func (b *BrowseService) browse(key string) error {
+	for _, kind := range *b.onlyArg {
+		if !slices.Contains(kinds, kind) {
+			errMsg := fmt.Sprintf("invalid --only: '%s'. (should be 'threads' | 'files' | 'assts')", kind)
+			err := errors.New(errMsg)
+			return err
+		}
+	}
+
+	var m *mirror.Mirror
+
+	if *b.offlineFlag {
+		var err error
+		m, err = mirror.Open(true)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	resources, err := b.getResources(key, m)
+
+	if m != nil {
+		m.Close() // So `oait sync` can run while browsing
+	}
+
+	if err != nil {
+		return err
+	}
+
+	marked, err := tui.Browse(resources)
+
+	if err != nil {
+		return err
+	}
+
+	if len(marked) == 0 {
+		fmt.Printf("Nothing deleted.\n")
+		return nil
+	}
+
+	for _, kind := range kinds {
+		if len(marked[kind]) > 0 {
+			err = b.delete(key, kind, marked[kind])
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (b *BrowseService) getResources(key string, m *mirror.Mirror) ([]tui.Resource, error) {
+	args := b.command.GetArgs()
+	threadsParsed := args[1].GetParsed() || args[2].GetParsed() || args[3].GetParsed()
+	resources := []tui.Resource{}
+
+	if b.browses("threads") && (threadsParsed || m != nil || slices.Contains(*b.onlyArg, "threads")) {
+		threads, err := b.getThreads(key, m)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, tui.ThreadsResource(threads))
+	}
+
+	if b.browses("files") {
+		fmt.Printf("Retrieving files...\t\t")
+		var fileObjects *[]openai.FileObject
+		var err error
+
+		if m != nil {
+			fileObjects, _, err = m.Files(nil, false)
+		} else {
+			fileObjects = openai.RetrieveAllFiles(key, *b.orgArg)
+
+			if fileObjects == nil {
+				err = errors.New("Failed retrieving files")
+			}
+		}
+
+		if err == nil {
+			fileObjects, err = filterDays(&args, fileObjects, *b.timeLTEArg, *b.timeGTArg)
+		}
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return nil, err
+		}
+		fmt.Printf("✓\n")
+
+		resources = append(resources, tui.FilesResource(*fileObjects))
+	}
+
+	if b.browses("assts") {
+		fmt.Printf("Retrieving assts...\t\t")
+		var asstObjects *[]openai.AsstObject
+		var err error
+
+		if m != nil {
+			asstObjects, _, err = m.Assts(nil, false)
+		} else {
+			asstObjects, err = openai.RetrieveAllAssts(key, *b.orgArg)
+		}
+
+		if err == nil {
+			asstObjects, err = filterDays(&args, asstObjects, *b.timeLTEArg, *b.timeGTArg)
+		}
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return nil, err
+		}
+		fmt.Printf("✓\n")
+
+		resources = append(resources, tui.AsstsResource(*asstObjects))
+	}
+
+	return resources, nil
+}
+
+// getThreads are the threads passed, or every mirrored one offline.
+func (b *BrowseService) getThreads(key string, m *mirror.Mirror) ([]tui.Thread, error) {
+	args := b.command.GetArgs()
+
+	fmt.Printf("Retrieving thread ids...\t")
+	threadIDs, err := b.getThreadIDs(&args, m)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return nil, err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Retrieving threads...\t\t")
+	var rawThreads *[]openai.Messages
+	var missing []string
+
+	if m != nil {
+		rawThreads, missing, err = m.ThreadsMessages(threadIDs)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return nil, err
+		}
+	} else {
+		rawThreads = openai.RetrieveThreadsMessages(key, threadIDs, *b.orgArg)
+	}
+
+	threads := make([]tui.Thread, len(threadIDs))
+
+	for i, threadID := range threadIDs {
+		threads[i] = tui.Thread{ID: threadID, Messages: (*rawThreads)[i]}
+	}
+
+	filtered, err := filterDays(&args, &threads, *b.timeLTEArg, *b.timeGTArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return nil, err
+	}
+	fmt.Printf("✓\n")
+
+	if len(missing) > 0 {
+		fmt.Printf("WARNING: %v threads not in the mirror (see `oait sync`): %v\n", len(missing), strings.Join(missing, " "))
+	}
+
+	return *filtered, nil
+}
+
+func (b *BrowseService) getThreadIDs(args *[]argparse.Arg, m *mirror.Mirror) ([]string, error) {
+	threadsParsed := (*args)[1].GetParsed()
+	inputParsed := (*args)[2].GetParsed()
+	sessionParsed := (*args)[3].GetParsed()
+
+	if threadsParsed {
+		return io.ListInput(*b.threadsArg)
+
+	} else if inputParsed {
+		return io.FileInput(*b.inputArg)
+
+	} else if sessionParsed {
+		return io.SessionInput(*b.sessionArg, *b.orgArg)
+
+	} else if m != nil { // Every mirrored thread
+		return m.ThreadIDs(false)
+	}
+
+	errMsg := fmt.Sprintf("No thread input options passed to `%v` (threads can't be listed; pass -i, -f or -s, or --offline)\n", b.name)
+	err := errors.New(errMsg)
+
+	return nil, err
+}
+
+func (b *BrowseService) browses(kind string) bool {
+	return len(*b.onlyArg) == 0 || slices.Contains(*b.onlyArg, kind)
+}
+
+// delete deletes what was marked and confirmed while browsing, as the del commands
+// do: sparing protected items, and backing up threads and assts first.
+func (b *BrowseService) delete(key string, kind string, ids []string) error {
+	ids, err := protect.Filter(key, kind, ids, *b.orgArg, *b.forceProtectedFlag)
+
+	if err != nil || len(ids) < 1 {
+		return err
+	}
+
+	if !*b.noBackupFlag {
+		err = backup.Before(key, kind, ids, *b.orgArg, *b.backupArg)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	audit.LogConfirmed(kind+" del", *b.orgArg, ids, false)
+
+	fmt.Printf("Deleting %v...\t\t", kind)
+	numDeleted := 0
+
+	switch kind {
+	case "threads":
+		numDeleted = openai.DeleteThreads(key, ids, *b.orgArg)
+	case "files":
+		numDeleted = openai.DeleteFiles(key, ids, *b.orgArg)
+	case "assts":
+		numDeleted = openai.DeleteAssts(key, ids, *b.orgArg)
+	}
+	fmt.Printf("✓\n")
+	fmt.Printf("Deleted %v %v.\n", numDeleted, kind)
+
+	return nil
+}
+
+func filterDays[T filter.CreatedAtProvider](args *[]argparse.Arg, list *[]T, timeLTE float64, timeGT float64) (*[]T, error) {
+	filtered := list
+	var err error
+
+	if (*args)[5].GetParsed() {
+		filtered, err = filter.DaysLTE(filtered, timeLTE)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if (*args)[6].GetParsed() {
+		filtered, err = filter.DaysGT(filtered, timeGT)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return filtered, nil
+}
@@ -0,0 +1,284 @@
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/chat"
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/schema"
+
+	"github.com/akamensky/argparse"
+)
+
+type CompleteCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	modelArg      *string
+	promptArg     *string
+	systemArg     *string
+	stdinFlag     *bool
+	inputArg      *string
+	tempArg       *float64
+	maxTokensArg  *int
+	toolArg       *[]string
+	resFormArg    *string
+	jsonSchemaArg *string
+	seedArg       *int
+	noStreamFlag  *bool
+	saveFlag      *bool
+	outputArg     *string
+	orgArg        *string
+}
+
+func NewCompleteCommand(command *argparse.Command) *CompleteCommand {
+	const name = "complete"
+	const desc = "Complete Chat Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	modelArg := subCommand.String("m", "model", &argparse.Options{Required: false, Help: "OpenAI Model (default to conversation file model, or 'gpt-4o-mini')"})
+	promptArg := subCommand.String("p", "prompt", &argparse.Options{Required: false, Help: "User message"})
+	systemArg := subCommand.String("", "system", &argparse.Options{Required: false, Help: "System message (prepended)"})
+	stdinFlag := subCommand.Flag("", "stdin", &argparse.Options{Required: false, Help: "Read user message from stdin"})
+	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: false, Help: "Conversation file (.json | .yaml | .yml)"})
+	tempArg := subCommand.Float("t", "temp", &argparse.Options{Required: false, Help: "Temperature <0.0 - 2.0>"})
+	maxTokensArg := subCommand.Int("", "max-tokens", &argparse.Options{Required: false, Help: "Max completion tokens"})
+	toolArg := subCommand.StringList("", "tool", &argparse.Options{Required: false, Help: "Add function tool from JSON file <function:<file> | <file>>"})
+	resFormArg := subCommand.String("r", "resformat", &argparse.Options{Required: false, Help: "Response format <'text' | 'json_object'>"})
+	jsonSchemaArg := subCommand.String("", "json-schema", &argparse.Options{Required: false, Help: "Respond with json_schema response format from JSON Schema file"})
+	seedArg := subCommand.Int("", "seed", &argparse.Options{Required: false, Help: "Seed for deterministic sampling"})
+	noStreamFlag := subCommand.Flag("", "no-stream", &argparse.Options{Required: false, Help: "Wait for the whole reply instead of streaming"})
+	saveFlag := subCommand.Flag("", "save", &argparse.Options{Required: false, Help: "Append the message(s) and reply to the conversation file"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Reply File Output"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+
+	return &CompleteCommand{
+		name,
+		desc,
+		subCommand,
+		modelArg,
+		promptArg,
+		systemArg,
+		stdinFlag,
+		inputArg,
+		tempArg,
+		maxTokensArg,
+		toolArg,
+		resFormArg,
+		jsonSchemaArg,
+		seedArg,
+		noStreamFlag,
+		saveFlag,
+		outputArg,
+		orgArg,
+	}
+}
+
+func (c *CompleteCommand) Happened() bool {
+
+	return c.command.Happened()
+}
+
+func (c *CompleteCommand) Run(key string) error {
+	args := c.command.GetArgs()
+	inputParsed := args[5].GetParsed()
+
+	if *c.saveFlag && !inputParsed {
+		err := errors.New("--save needs a conversation file (-f).")
+		return err
+	}
+
+	conversation := &chat.Conversation{}
+	var err error
+
+	if inputParsed {
+		conversation, err = chat.Load(*c.inputArg)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.addMessages(conversation)
+
+	if err != nil {
+		return err
+	}
+
+	chatReq, err := c.getChatRequest(&args, conversation)
+
+	if err != nil {
+		return err
+	}
+
+	var reply *openai.ChatMessage
+
+	if *c.noStreamFlag {
+		completion, err := openai.NewChatCompletion(key, chatReq, *c.orgArg)
+
+		if err != nil {
+			return err
+		}
+
+		if len(completion.Choices) < 1 {
+			err := errors.New("Completion returned no choices.")
+			return err
+		}
+
+		reply = &completion.Choices[0].Message
+		fmt.Printf("%v", reply.Content)
+
+	} else {
+		reply, err = openai.CompleteChat(key, chatReq, *c.orgArg, func(content string) {
+			fmt.Printf("%v", content)
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if reply.Content != "" {
+		fmt.Printf("\n")
+	}
+
+	if len(reply.ToolCalls) > 0 {
+		toolCallsOutput, err := io.ListToJSON(&reply.ToolCalls)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%v\n", string(toolCallsOutput))
+	}
+
+	if *c.outputArg != "" {
+		replyOutput := []byte(reply.Content)
+		err = io.FileOutput(*c.outputArg, &replyOutput)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if *c.saveFlag {
+		conversation.Messages = append(conversation.Messages, *reply)
+		err = conversation.Save(*c.inputArg)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addMessages appends the system and user messages given by flags or stdin. The
+// system message replaces a leading one, so a saved conversation keeps just one.
+func (c *CompleteCommand) addMessages(conversation *chat.Conversation) error {
+	if *c.systemArg != "" {
+		systemMessage := openai.ChatMessage{Role: "system", Content: *c.systemArg}
+
+		if len(conversation.Messages) > 0 && conversation.Messages[0].Role == "system" {
+			conversation.Messages[0] = systemMessage
+		} else {
+			conversation.Messages = append([]openai.ChatMessage{systemMessage}, conversation.Messages...)
+		}
+	}
+
+	userMessages := []string{}
+
+	if *c.promptArg != "" {
+		userMessages = append(userMessages, *c.promptArg)
+	}
+
+	if *c.stdinFlag {
+		stdinMessage, err := io.StdinInput()
+
+		if err != nil {
+			return err
+		}
+
+		userMessages = append(userMessages, stdinMessage)
+	}
+
+	if len(userMessages) > 0 {
+		userMessage := openai.ChatMessage{Role: "user", Content: strings.Join(userMessages, "\n\n")}
+		conversation.Messages = append(conversation.Messages, userMessage)
+	}
+
+	if len(conversation.Messages) < 1 {
+		errMsg := fmt.Sprintf("No messages passed to `%v` (use -p, --stdin or -f)\n", c.name)
+		err := errors.New(errMsg)
+		return err
+	}
+
+	return nil
+}
+
+func (c *CompleteCommand) getChatRequest(args *[]argparse.Arg, conversation *chat.Conversation) (*openai.ChatRequest, error) {
+	tempParsed := (*args)[6].GetParsed()
+	maxTokensParsed := (*args)[7].GetParsed()
+	seedParsed := (*args)[11].GetParsed()
+
+	chatReq := openai.ChatRequest{
+		Model:    *c.modelArg,
+		Messages: conversation.Messages,
+	}
+
+	if chatReq.Model == "" {
+		chatReq.Model = conversation.Model
+	}
+
+	if chatReq.Model == "" {
+		chatReq.Model = "gpt-4o-mini"
+	}
+
+	if tempParsed {
+		chatReq.Temp = c.tempArg
+	}
+
+	if maxTokensParsed {
+		chatReq.MaxTokens = c.maxTokensArg
+	}
+
+	if seedParsed {
+		chatReq.Seed = c.seedArg
+	}
+
+	for _, toolStr := range *c.toolArg {
+		tool, err := schema.ToolInput(strings.TrimPrefix(toolStr, "function:"))
+
+		if err != nil {
+			return nil, err
+		}
+
+		chatReq.Tools = append(chatReq.Tools, *tool)
+	}
+
+	if *c.jsonSchemaArg != "" {
+		jsonSchema, err := schema.JSONSchemaInput(*c.jsonSchemaArg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		chatReq.ResponseFormat = &openai.ResponseFormat{Type: "json_schema", JSONSchema: jsonSchema}
+
+	} else if *c.resFormArg != "" {
+		if *c.resFormArg != "text" && *c.resFormArg != "json_object" {
+			errMsg := fmt.Sprintf("invalid response format: '%s'. (should be 'text' | 'json_object', or use --json-schema)", *c.resFormArg)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+
+		chatReq.ResponseFormat = &openai.ResponseFormat{Type: *c.resFormArg}
+	}
+
+	return &chatReq, nil
+}
@@ -0,0 +1,53 @@
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/akamensky/argparse"
+)
+
+type ChatService struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	completeCommand *CompleteCommand
+}
+
+func NewService(parser *argparse.Parser) *ChatService {
+	const name = "chat"
+	const desc = "Chat Completions Tools"
+
+	service := parser.NewCommand(name, desc)
+
+	complete := NewCompleteCommand(service)
+
+	return &ChatService{
+		name,
+		desc,
+		service,
+		complete,
+	}
+}
+
+func (c *ChatService) Run(key string) error {
+
+	if c.completeCommand.Happened() {
+		err := c.completeCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else {
+		errMsg := fmt.Sprintf("No command given to `%v`\n", c.name)
+		helpMsg := c.command.Help(errMsg)
+		err := errors.New(helpMsg)
+		return err
+	}
+
+	return nil
+}
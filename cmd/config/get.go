@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/config"
+
+	"github.com/akamensky/argparse"
+)
+
+type GetCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	fieldArg *string
+}
+
+func NewGetCommand(command *argparse.Command) *GetCommand {
+	const name = "get"
+	const desc = "Get Profile Settings"
+
+	subCommand := command.NewCommand(name, desc)
+
+	fieldArg := subCommand.String("f", "field", &argparse.Options{Required: false, Help: "Setting to get <key | key_command | org | project | base_url | concurrency | output> (default all, key masked)"})
+
+	return &GetCommand{
+		name,
+		desc,
+		subCommand,
+		fieldArg,
+	}
+}
+
+func (g *GetCommand) Happened() bool {
+
+	return g.command.Happened()
+}
+
+func (g *GetCommand) Run(profileFlag string) error {
+	cfg, err := config.Load()
+
+	if err != nil {
+		return err
+	}
+
+	name, profile, err := cfg.Resolve(profileFlag)
+
+	if err != nil {
+		return err
+	}
+
+	if *g.fieldArg != "" {
+		val, err := profile.Get(*g.fieldArg)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%v\n", val)
+		return nil
+	}
+
+	fmt.Printf("Profile '%v':\n%v\n", name, profile.Format())
+
+	return nil
+}
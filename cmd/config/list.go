@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/config"
+
+	"github.com/akamensky/argparse"
+)
+
+type ListCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+}
+
+func NewListCommand(command *argparse.Command) *ListCommand {
+	const name = "list"
+	const desc = "List Profiles"
+
+	subCommand := command.NewCommand(name, desc)
+
+	return &ListCommand{
+		name,
+		desc,
+		subCommand,
+	}
+}
+
+func (l *ListCommand) Happened() bool {
+
+	return l.command.Happened()
+}
+
+func (l *ListCommand) Run(profileFlag string) error {
+	cfg, err := config.Load()
+
+	if err != nil {
+		return err
+	}
+
+	fileName, err := config.Path()
+
+	if err != nil {
+		return err
+	}
+
+	names := cfg.Names()
+
+	if len(names) < 1 {
+		fmt.Printf("No profiles in '%v'. Add one with `oait config set`.\n", fileName)
+		return nil
+	}
+
+	selected, _ := cfg.Selected(profileFlag)
+
+	for _, name := range names {
+		profile := cfg.Profiles[name]
+		marker := " "
+
+		if name == selected {
+			marker = "*"
+		}
+
+		fmt.Printf("%v %v\n", marker, name)
+
+		if settings := profile.Format(); settings != "" {
+			fmt.Printf("    %v\n", strings.ReplaceAll(settings, "\n", "\n    "))
+		}
+	}
+
+	return nil
+}
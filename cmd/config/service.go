@@ -0,0 +1,87 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/akamensky/argparse"
+)
+
+type ConfigService struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	getCommand  *GetCommand
+	setCommand  *SetCommand
+	listCommand *ListCommand
+	useCommand  *UseCommand
+}
+
+func NewService(parser *argparse.Parser) *ConfigService {
+	const name = "config"
+	const desc = "Config Profiles Tools"
+
+	service := parser.NewCommand(name, desc)
+
+	get := NewGetCommand(service)
+	set := NewSetCommand(service)
+	list := NewListCommand(service)
+	use := NewUseCommand(service)
+
+	return &ConfigService{
+		name,
+		desc,
+		service,
+		get,
+		set,
+		list,
+		use,
+	}
+}
+
+// Run takes the --profile flag rather than a key: config commands work on profiles.
+func (c *ConfigService) Run(profileFlag string) error {
+
+	if c.getCommand.Happened() {
+		err := c.getCommand.Run(profileFlag)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if c.setCommand.Happened() {
+		err := c.setCommand.Run(profileFlag)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if c.listCommand.Happened() {
+		err := c.listCommand.Run(profileFlag)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if c.useCommand.Happened() {
+		err := c.useCommand.Run(profileFlag)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else {
+		errMsg := fmt.Sprintf("No command given to `%v`\n", c.name)
+		helpMsg := c.command.Help(errMsg)
+		err := errors.New(helpMsg)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/config"
+
+	"github.com/akamensky/argparse"
+)
+
+type SetCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	setArg *[]string
+}
+
+func NewSetCommand(command *argparse.Command) *SetCommand {
+	const name = "set"
+	const desc = "Set Profile Settings"
+
+	subCommand := command.NewCommand(name, desc)
+
+	setArg := subCommand.StringList("s", "set", &argparse.Options{Required: true, Help: "Set <field>=<value> (empty value unsets; creates the profile if needed)"})
+
+	return &SetCommand{
+		name,
+		desc,
+		subCommand,
+		setArg,
+	}
+}
+
+func (s *SetCommand) Happened() bool {
+
+	return s.command.Happened()
+}
+
+func (s *SetCommand) Run(profileFlag string) error {
+	cfg, err := config.Load()
+
+	if err != nil {
+		return err
+	}
+
+	name, _ := cfg.Selected(profileFlag)
+	profile := cfg.Profiles[name]
+
+	for _, setStr := range *s.setArg {
+		setSplit := strings.SplitN(setStr, "=", 2)
+
+		if len(setSplit) < 2 {
+			errMsg := fmt.Sprintf("invalid setting: '%s'. (should be '<field>=<value>')", setStr)
+			err = errors.New(errMsg)
+			return err
+		}
+
+		err = profile.Set(setSplit[0], setSplit[1])
+
+		if err != nil {
+			return err
+		}
+	}
+
+	cfg.Profiles[name] = profile
+
+	if cfg.Current == "" {
+		cfg.Current = name
+	}
+
+	err = cfg.Save()
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Updated profile '%v':\n%v\n", name, profile.Format())
+
+	return nil
+}
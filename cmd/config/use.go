@@ -0,0 +1,66 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/config"
+
+	"github.com/akamensky/argparse"
+)
+
+type UseCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	nameArg *string
+}
+
+func NewUseCommand(command *argparse.Command) *UseCommand {
+	const name = "use"
+	const desc = "Set Current Profile"
+
+	subCommand := command.NewCommand(name, desc)
+
+	nameArg := subCommand.String("n", "name", &argparse.Options{Required: true, Help: "Profile to make current"})
+
+	return &UseCommand{
+		name,
+		desc,
+		subCommand,
+		nameArg,
+	}
+}
+
+func (u *UseCommand) Happened() bool {
+
+	return u.command.Happened()
+}
+
+func (u *UseCommand) Run(profileFlag string) error {
+	cfg, err := config.Load()
+
+	if err != nil {
+		return err
+	}
+
+	_, ok := cfg.Profiles[*u.nameArg]
+
+	if !ok {
+		errMsg := fmt.Sprintf("Unknown profile: '%v'. (see `oait config list`)", *u.nameArg)
+		err = errors.New(errMsg)
+		return err
+	}
+
+	cfg.Current = *u.nameArg
+	err = cfg.Save()
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Using profile '%v'.\n", cfg.Current)
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package embeddings
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/akamensky/argparse"
+
+	"github.com/jackitaliano/oait/internal/embed"
+	"github.com/jackitaliano/oait/internal/openai"
+)
+
+const maxBatchItems = 2048
+
+type EmbeddingsService struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	inputArg       *string
+	filesArg       *[]string
+	stdinFlag      *bool
+	outputArg      *string
+	formatArg      *string
+	modelArg       *string
+	dimensionsArg  *int
+	idFieldArg     *string
+	textFieldArg   *string
+	batchSizeArg   *int
+	batchTokensArg *int
+	resumeFlag     *bool
+	orgArg         *string
+}
+
+func NewService(parser *argparse.Parser) *EmbeddingsService {
+	const name = "embeddings"
+	const desc = "Embeddings Tools"
+
+	service := parser.NewCommand(name, desc)
+
+	inputArg := service.String("i", "input", &argparse.Options{Required: false, Help: "Text file of one input per line, or .jsonl records"})
+	filesArg := service.StringList("f", "files", &argparse.Options{Required: false, Help: "Files or directories to embed, one input per file"})
+	stdinFlag := service.Flag("", "stdin", &argparse.Options{Required: false, Help: "Read one input per line from stdin"})
+	outputArg := service.String("o", "output", &argparse.Options{Required: true, Help: "Embeddings Output (.ndjson | .bin | .npy)"})
+	formatArg := service.String("", "format", &argparse.Options{Required: false, Help: "Output format <ndjson | bin | npy> (default from output extension, or ndjson)"})
+	modelArg := service.String("m", "model", &argparse.Options{Required: false, Help: "OpenAI Model", Default: "text-embedding-3-small"})
+	dimensionsArg := service.Int("", "dimensions", &argparse.Options{Required: false, Help: "Embedding dimensions (text-embedding-3 models only)"})
+	idFieldArg := service.String("", "id-field", &argparse.Options{Required: false, Help: "JSONL field holding the record ID", Default: "id"})
+	textFieldArg := service.String("", "text-field", &argparse.Options{Required: false, Help: "JSONL field holding the record text", Default: "text"})
+	batchSizeArg := service.Int("", "batch-size", &argparse.Options{Required: false, Help: "Max inputs per request (max 2048)", Default: 512})
+	batchTokensArg := service.Int("", "batch-tokens", &argparse.Options{Required: false, Help: "Max estimated tokens per request", Default: 250000})
+	resumeFlag := service.Flag("", "resume", &argparse.Options{Required: false, Help: "Continue an interrupted run, skipping IDs already in the output"})
+	orgArg := service.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+
+	return &EmbeddingsService{
+		name,
+		desc,
+		service,
+		inputArg,
+		filesArg,
+		stdinFlag,
+		outputArg,
+		formatArg,
+		modelArg,
+		dimensionsArg,
+		idFieldArg,
+		textFieldArg,
+		batchSizeArg,
+		batchTokensArg,
+		resumeFlag,
+		orgArg,
+	}
+}
+
+func (e *EmbeddingsService) Run(key string) error {
+	err := e.embed(key)
+
+	if err != nil {
+		fmt.Printf("ERROR: %v\n", err.Error())
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func (e *EmbeddingsService) embed(key string) error {
+	args := e.command.GetArgs()
+	dimensionsParsed := args[7].GetParsed()
+
+	if *e.batchSizeArg < 1 || *e.batchSizeArg > maxBatchItems {
+		errMsg := fmt.Sprintf("Invalid batch size: %v. (should be 1 - %v)", *e.batchSizeArg, maxBatchItems)
+		err := errors.New(errMsg)
+		return err
+	}
+
+	format := *e.formatArg
+	if format == "" {
+		format = embed.FormatFromName(*e.outputArg)
+	}
+
+	fmt.Printf("Reading input...\t\t")
+	records, err := e.getRecords()
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	writer, err := embed.Open(*e.outputArg, format, *e.resumeFlag)
+
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	if len(writer.Done()) > 0 {
+		fmt.Printf("Resuming after %v embeddings in '%v'.\n", len(writer.Done()), *e.outputArg)
+	}
+
+	records, err = embed.Remaining(records, writer.Done())
+
+	if err != nil {
+		return err
+	}
+
+	batches, err := embed.Batches(records, *e.batchSizeArg, *e.batchTokensArg)
+
+	if err != nil {
+		return err
+	}
+
+	embeddingReq := openai.EmbeddingRequest{
+		Model: *e.modelArg,
+	}
+
+	if dimensionsParsed {
+		embeddingReq.Dimensions = e.dimensionsArg
+	}
+
+	totalTokens := 0
+
+	for i, batch := range batches {
+		ids := make([]string, len(batch))
+		embeddingReq.Input = make([]string, len(batch))
+
+		for j, record := range batch {
+			ids[j] = record.ID
+			embeddingReq.Input[j] = record.Text
+		}
+
+		fmt.Printf("Embedding batch %v/%v (%v inputs)...\t", i+1, len(batches), len(batch))
+		embeddingsRes, err := openai.NewEmbeddings(key, &embeddingReq, *e.orgArg)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+
+		embeddings, err := ordered(embeddingsRes, len(batch))
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+
+		err = writer.Write(ids, embeddings)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+		fmt.Printf("✓\n")
+
+		if embeddingsRes.Usage != nil {
+			totalTokens += embeddingsRes.Usage.TotalTokens
+		}
+	}
+
+	fmt.Printf("Embedded %v inputs (%v tokens) to '%v'.\n", len(records), totalTokens, *e.outputArg)
+
+	return nil
+}
+
+func (e *EmbeddingsService) getRecords() ([]embed.Record, error) {
+	numInputs := 0
+	for _, given := range []bool{*e.inputArg != "", len(*e.filesArg) > 0, *e.stdinFlag} {
+		if given {
+			numInputs += 1
+		}
+	}
+
+	if numInputs != 1 {
+		err := errors.New("Must provide exactly one of input (-i), files (-f) or --stdin")
+		return nil, err
+	}
+
+	if *e.stdinFlag {
+		return embed.LinesInput(os.Stdin, "stdin")
+	}
+
+	if len(*e.filesArg) > 0 {
+		return embed.FilesInput(*e.filesArg)
+	}
+
+	if strings.HasSuffix(*e.inputArg, ".jsonl") {
+		return embed.JSONLInput(*e.inputArg, *e.idFieldArg, *e.textFieldArg)
+	}
+
+	file, err := os.Open(*e.inputArg)
+
+	if err != nil {
+		err = errors.New("Failed reading input: " + *e.inputArg + ". Error: " + err.Error())
+		return nil, err
+	}
+	defer file.Close()
+
+	return embed.LinesInput(file, *e.inputArg)
+}
+
+// ordered returns the embeddings in input order, checking one came back per input.
+func ordered(embeddingsRes *openai.EmbeddingsResponse, numInputs int) ([][]float32, error) {
+	embeddings := make([][]float32, numInputs)
+
+	for _, embedding := range embeddingsRes.Data {
+		if embedding.Index < 0 || embedding.Index >= numInputs {
+			errMsg := fmt.Sprintf("Unexpected embedding index: %v", embedding.Index)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+
+		embeddings[embedding.Index] = embedding.Embedding
+	}
+
+	for i, embedding := range embeddings {
+		if embedding == nil {
+			errMsg := fmt.Sprintf("Missing embedding for input %v of %v", i+1, numInputs)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+	}
+
+	return embeddings, nil
+}
@@ -6,9 +6,12 @@ import (
 
 	"github.com/akamensky/argparse"
 
+	"github.com/jackitaliano/oait/internal/audit"
 	"github.com/jackitaliano/oait/internal/filter"
 	"github.com/jackitaliano/oait/internal/io"
 	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/plan"
+	"github.com/jackitaliano/oait/internal/protect"
 	"github.com/jackitaliano/oait/internal/tui"
 )
 
@@ -26,6 +29,12 @@ type DelCommand struct {
 	timeGTArg  *float64
 	nameContainsArg    *[]string
 	nameNotContainsArg *[]string
+	yesFlag            *bool
+	noVerifyFlag       *bool
+	dryRunFlag         *bool
+	applyPlanArg       *string
+	forceProtectedFlag *bool
+	maxDeleteArg       *int
 }
 
 func NewDelCommand(command *argparse.Command) *DelCommand {
@@ -43,6 +52,12 @@ func NewDelCommand(command *argparse.Command) *DelCommand {
 	timeGTArg := subCommand.Float("D", "Days", &argparse.Options{Required: false, Help: "Filter by GT days"})
 	nameContainsArg := subCommand.StringList("n", "name", &argparse.Options{Required: false, Help: "Filter by File containing name"})
 	nameNotContainsArg := subCommand.StringList("N", "Name", &argparse.Options{Required: false, Help: "Filter by File not containing name"})
+	yesFlag := subCommand.Flag("y", "yes", &argparse.Options{Required: false, Help: "Answer yes to all prompts"})
+	noVerifyFlag := subCommand.Flag("", "no-verify", &argparse.Options{Required: false, Help: "Skip verification prompt"})
+	dryRunFlag := subCommand.Flag("", "dry-run", &argparse.Options{Required: false, Help: "Output deletion plan without deleting"})
+	applyPlanArg := subCommand.String("", "apply-plan", &argparse.Options{Required: false, Help: "Delete exactly the files in plan file (from --dry-run)"})
+	forceProtectedFlag := subCommand.Flag("", "force-protected", &argparse.Options{Required: false, Help: "Include files matched by the protection policy"})
+	maxDeleteArg := subCommand.Int("", "max-delete", &argparse.Options{Required: false, Help: "Abort if more than N files would be deleted"})
 
 	return &DelCommand{
 		name,
@@ -57,6 +72,12 @@ func NewDelCommand(command *argparse.Command) *DelCommand {
 		timeGTArg,
 		nameContainsArg,
 		nameNotContainsArg,
+		yesFlag,
+		noVerifyFlag,
+		dryRunFlag,
+		applyPlanArg,
+		forceProtectedFlag,
+		maxDeleteArg,
 	}
 }
 
@@ -67,6 +88,11 @@ func (d *DelCommand) Happened() bool {
 func (d *DelCommand) Run(key string) error {
 	args := d.command.GetArgs()
 	allParsed := args[3].GetParsed()
+	applyPlanParsed := args[13].GetParsed()
+
+	if applyPlanParsed {
+		return d.applyPlan(key)
+	}
 
 	var fileObjects *[]openai.FileObject
 
@@ -100,9 +126,21 @@ func (d *DelCommand) Run(key string) error {
 	}
 	fmt.Printf("✓\n")
 
-	deleteFileIDs := getFileIDsFromObjects(filteredFileObjects)
+	deleteFileIDs, err := protect.Filter(key, "files", getFileIDsFromObjects(filteredFileObjects), *d.orgArg, *d.forceProtectedFlag)
 
-	verify := verifyBeforeDelete()
+	if err != nil {
+		return err
+	}
+
+	if *d.dryRunFlag {
+		return d.outputPlan(&args, filteredFileObjects, deleteFileIDs)
+	}
+
+	verify, err := verifyBeforeDelete(*d.noVerifyFlag, *d.yesFlag)
+
+	if err != nil {
+		return err
+	}
 
 	if verify {
 		fmt.Printf("Formatting files output...\t")
@@ -122,9 +160,74 @@ func (d *DelCommand) Run(key string) error {
 		}
 	}
 
-	confirmed := confirmDelete()
+	return d.deleteFiles(key, deleteFileIDs)
+}
+
+func (d *DelCommand) applyPlan(key string) error {
+	fmt.Printf("Reading plan...\t\t\t")
+	deletePlan, err := io.JSONInput[plan.Plan](*d.applyPlanArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+
+	deleteFileIDs, err := deletePlan.GetIDs("files")
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	if *d.orgArg == "" { // Delete from the org the plan was made in
+		*d.orgArg = deletePlan.OrgID
+	}
+
+	deleteFileIDs, err = protect.Filter(key, "files", deleteFileIDs, *d.orgArg, *d.forceProtectedFlag)
+
+	if err != nil {
+		return err
+	}
+
+	verify, err := verifyBeforeDelete(*d.noVerifyFlag, *d.yesFlag)
+
+	if err != nil {
+		return err
+	}
+
+	if verify {
+		planOutput, err := io.ObjToJSON(deletePlan)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Outputting plan... \n\n")
+		fmt.Printf("%v\n", string(planOutput))
+	}
+
+	return d.deleteFiles(key, deleteFileIDs)
+}
+
+func (d *DelCommand) deleteFiles(key string, deleteFileIDs []string) error {
+	maxDeleteParsed := d.command.GetArgs()[15].GetParsed()
+
+	if maxDeleteParsed && len(deleteFileIDs) > *d.maxDeleteArg {
+		errMsg := fmt.Sprintf("Refusing to delete %v files: more than --max-delete %v", len(deleteFileIDs), *d.maxDeleteArg)
+		err := errors.New(errMsg)
+		return err
+	}
+
+	confirmed, err := confirmDelete(*d.yesFlag)
+
+	if err != nil {
+		return err
+	}
 
 	if confirmed {
+		audit.LogConfirmed("files del", *d.orgArg, deleteFileIDs, *d.yesFlag)
+
 		fmt.Printf("Deleting files...\t\t")
 		numDeleted := openai.DeleteFiles(key, deleteFileIDs, *d.orgArg)
 		fmt.Printf("✓\n")
@@ -136,12 +239,63 @@ func (d *DelCommand) Run(key string) error {
 	return nil
 }
 
-func verifyBeforeDelete() bool {
-	return tui.YesNoLoop("Verify files before deletion?")
+func (d *DelCommand) outputPlan(args *[]argparse.Arg, filteredFileObjects *[]openai.FileObject, deleteFileIDs []string) error {
+	fmt.Printf("Formatting plan output...\t")
+	items := plan.KeepIDs(plan.FileItems(filteredFileObjects, d.getReasons(args)), deleteFileIDs)
+	deletePlan := plan.NewDeletePlan("files", *d.orgArg, items)
+
+	planOutput, err := io.ObjToJSON(deletePlan)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Outputting plan (dry run, %v files)... \n\n", len(items))
+	return d.outputFiles(args, &planOutput)
+}
+
+func (d *DelCommand) getReasons(args *[]argparse.Arg) []string {
+	reasons := []string{}
+
+	if (*args)[3].GetParsed() && *d.allFlag {
+		reasons = append(reasons, "all files")
+	} else if (*args)[1].GetParsed() {
+		reasons = append(reasons, "listed in --ids")
+	} else if (*args)[2].GetParsed() {
+		reasons = append(reasons, fmt.Sprintf("listed in '%v'", *d.inputArg))
+	}
+
+	if (*args)[6].GetParsed() {
+		reasons = append(reasons, fmt.Sprintf("created <= %v days ago", *d.timeLTEArg))
+	}
+
+	if (*args)[7].GetParsed() {
+		reasons = append(reasons, fmt.Sprintf("created > %v days ago", *d.timeGTArg))
+	}
+
+	for _, name := range *d.nameContainsArg {
+		reasons = append(reasons, fmt.Sprintf("name contains '%v'", name))
+	}
+
+	for _, name := range *d.nameNotContainsArg {
+		reasons = append(reasons, fmt.Sprintf("name not contains '%v'", name))
+	}
+
+	return reasons
+}
+
+func verifyBeforeDelete(noVerify bool, assumeYes bool) (bool, error) {
+	if noVerify {
+		return false, nil
+	}
+
+	return tui.Confirm("Verify files before deletion?", assumeYes)
 }
 
-func confirmDelete() bool {
-	return tui.YesNoLoop("Confirm deletion")
+func confirmDelete(assumeYes bool) (bool, error) {
+	return tui.Confirm("Confirm deletion", assumeYes)
 }
 
 func (d *DelCommand) getFileIDs(args *[]argparse.Arg) ([]string, error) {
@@ -224,7 +378,7 @@ func (d *DelCommand) getFilesOutput(args *[]argparse.Arg, filteredFileObjects *[
 }
 
 func (d *DelCommand) outputFiles(args *[]argparse.Arg, output *[]byte) error {
-	outputParsed := (*args)[4].GetParsed()
+	outputParsed := (*args)[5].GetParsed()
 
 	if outputParsed {
 		err := io.FileOutput(*d.outputArg, output)
@@ -3,9 +3,11 @@ package files
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/jackitaliano/oait/internal/filter"
 	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/mirror"
 	"github.com/jackitaliano/oait/internal/openai"
 
 	"github.com/akamensky/argparse"
@@ -25,6 +27,8 @@ type GetCommand struct {
 	timeGTArg  *float64
 	nameContainsArg    *[]string
 	nameNotContainsArg *[]string
+	offlineFlag        *bool
+	includeDeletedFlag *bool
 }
 
 func NewGetCommand(command *argparse.Command) *GetCommand {
@@ -42,6 +46,8 @@ func NewGetCommand(command *argparse.Command) *GetCommand {
 	timeGTArg := subCommand.Float("D", "Days", &argparse.Options{Required: false, Help: "Filter by GT days"})
 	nameContainsArg := subCommand.StringList("n", "name", &argparse.Options{Required: false, Help: "Filter by File containing name"})
 	nameNotContainsArg := subCommand.StringList("N", "Name", &argparse.Options{Required: false, Help: "Filter by File not containing name"})
+	offlineFlag := subCommand.Flag("", "offline", &argparse.Options{Required: false, Help: "Read files from the mirror (see `oait sync`)"})
+	includeDeletedFlag := subCommand.Flag("", "include-deleted", &argparse.Options{Required: false, Help: "With --offline and -A, include files deleted since mirroring"})
 
 	return &GetCommand{
 		name,
@@ -56,6 +62,8 @@ func NewGetCommand(command *argparse.Command) *GetCommand {
 		timeGTArg,
 		nameContainsArg,
 		nameNotContainsArg,
+		offlineFlag,
+		includeDeletedFlag,
 	}
 }
 
@@ -70,7 +78,15 @@ func (g *GetCommand) Run(key string) error {
 
 	var fileObjects *[]openai.FileObject
 
-	if allParsed && *g.allFlag {
+	if *g.offlineFlag {
+		var err error
+		fileObjects, err = g.retrieveMirroredFiles(&args)
+
+		if err != nil {
+			return err
+		}
+
+	} else if allParsed && *g.allFlag {
 		fmt.Printf("Retrieving all files...\t\t")
 		fileObjects = openai.RetrieveAllFiles(key, *g.orgArg)
 		fmt.Printf("✓\n")
@@ -150,6 +166,40 @@ func (g *GetCommand) getFileIDs(args *[]argparse.Arg) ([]string, error) {
 	return nil, err
 }
 
+func (g *GetCommand) retrieveMirroredFiles(args *[]argparse.Arg) (*[]openai.FileObject, error) {
+	m, err := mirror.Open(true)
+
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+
+	var fileIDs []string
+
+	if !*g.allFlag {
+		fileIDs, err = g.getFileIDs(args)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fmt.Printf("Retrieving mirrored files...\t")
+	fileObjects, missing, err := m.Files(fileIDs, *g.includeDeletedFlag)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return nil, err
+	}
+	fmt.Printf("✓\n")
+
+	if len(missing) > 0 {
+		fmt.Printf("WARNING: %v files not in the mirror (see `oait sync`): %v\n", len(missing), strings.Join(missing, " "))
+	}
+
+	return fileObjects, nil
+}
+
 func (g *GetCommand) filterFiles(args *[]argparse.Arg, fileObjects *[]openai.FileObject) (*[]openai.FileObject, error) {
 	timeLTEParsed := (*args)[6].GetParsed()
 	timeGTParsed := (*args)[7].GetParsed()
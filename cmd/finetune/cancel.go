@@ -0,0 +1,81 @@
+package finetune
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/audit"
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/tui"
+
+	"github.com/akamensky/argparse"
+)
+
+type CancelCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	jobsArg  *[]string
+	inputArg *string
+	orgArg   *string
+	yesFlag  *bool
+}
+
+func NewCancelCommand(command *argparse.Command) *CancelCommand {
+	const name = "cancel"
+	const desc = "Cancel Fine-tuning Jobs Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	jobsArg := subCommand.StringList("i", "ids", &argparse.Options{Required: false, Help: "List of Fine-tuning Job IDs"})
+	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: false, Help: "Job File Input (of ids)"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	yesFlag := subCommand.Flag("y", "yes", &argparse.Options{Required: false, Help: "Answer yes to all prompts"})
+
+	return &CancelCommand{
+		name,
+		desc,
+		subCommand,
+		jobsArg,
+		inputArg,
+		orgArg,
+		yesFlag,
+	}
+}
+
+func (c *CancelCommand) Happened() bool {
+
+	return c.command.Happened()
+}
+
+func (c *CancelCommand) Run(key string) error {
+	args := c.command.GetArgs()
+
+	jobIDs, err := getJobIDs(&args, c.jobsArg, c.inputArg, c.name)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Cancelling %v jobs:\n\t%v\n", len(jobIDs), strings.Join(jobIDs, "\n\t"))
+	confirmed, err := tui.Confirm("Confirm cancellation", *c.yesFlag)
+
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		fmt.Printf("Cancelled.\n")
+		return nil
+	}
+
+	audit.LogConfirmed("finetune cancel", *c.orgArg, jobIDs, *c.yesFlag)
+
+	fmt.Printf("Cancelling jobs...\t\t")
+	numCancelled := openai.CancelFineTuningJobs(key, jobIDs, *c.orgArg)
+	fmt.Printf("✓\n")
+	fmt.Printf("Cancelled %v jobs.\n", numCancelled)
+
+	return nil
+}
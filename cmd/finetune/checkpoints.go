@@ -0,0 +1,97 @@
+package finetune
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+
+	"github.com/akamensky/argparse"
+)
+
+type CheckpointsCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	jobArg     *string
+	orgArg     *string
+	outputArg  *string
+	prettyFlag *bool
+}
+
+func NewCheckpointsCommand(command *argparse.Command) *CheckpointsCommand {
+	const name = "checkpoints"
+	const desc = "Fine-tuning Job Checkpoints Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	jobArg := subCommand.String("i", "id", &argparse.Options{Required: true, Help: "Fine-tuning Job ID"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Checkpoint File Output"})
+	prettyFlag := subCommand.Flag("p", "pretty", &argparse.Options{Required: false, Help: "Pretty print checkpoints, one per line with metrics"})
+
+	return &CheckpointsCommand{
+		name,
+		desc,
+		subCommand,
+		jobArg,
+		orgArg,
+		outputArg,
+		prettyFlag,
+	}
+}
+
+func (c *CheckpointsCommand) Happened() bool {
+
+	return c.command.Happened()
+}
+
+func (c *CheckpointsCommand) Run(key string) error {
+	fmt.Printf("Retrieving checkpoints...\t")
+	checkpoints, err := openai.RetrieveAllFineTuningCheckpoints(key, *c.jobArg, *c.orgArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	var checkpointsOutput []byte
+
+	if *c.prettyFlag {
+		checkpointsOutput = []byte(formatCheckpoints(checkpoints))
+	} else {
+		checkpointsOutput, err = io.ListToJSON(&checkpoints)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Outputting checkpoints... \n\n")
+	return outputJobs(&checkpointsOutput, *c.outputArg)
+}
+
+func formatCheckpoints(checkpoints []openai.FineTuningCheckpoint) string {
+	lines := []string{}
+
+	for _, checkpoint := range checkpoints {
+		metricNames := make([]string, 0, len(checkpoint.Metrics))
+		for metricName := range checkpoint.Metrics {
+			metricNames = append(metricNames, metricName)
+		}
+		sort.Strings(metricNames)
+
+		metrics := []string{}
+		for _, metricName := range metricNames {
+			metrics = append(metrics, fmt.Sprintf("%v=%.4f", metricName, checkpoint.Metrics[metricName]))
+		}
+
+		lines = append(lines, fmt.Sprintf("step %-6v %v\n\t%v", checkpoint.StepNumber, checkpoint.FineTunedModelCheckpoint, strings.Join(metrics, " ")))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,288 @@
+package finetune
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/audit"
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/tui"
+
+	"github.com/akamensky/argparse"
+)
+
+type CreateCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	modelArg        *string
+	trainingArg     *string
+	validationArg   *string
+	epochsArg       *string
+	batchSizeArg    *string
+	lrMultiplierArg *string
+	suffixArg       *string
+	seedArg         *int
+	metadataArg     *[]string
+	orgArg          *string
+	yesFlag         *bool
+	noValidateFlag  *bool
+	priceArg        *float64
+}
+
+func NewCreateCommand(command *argparse.Command) *CreateCommand {
+	const name = "create"
+	const desc = "Create Fine-tuning Job Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	modelArg := subCommand.String("m", "model", &argparse.Options{Required: true, Help: "Base model to fine-tune"})
+	trainingArg := subCommand.String("t", "training", &argparse.Options{Required: true, Help: "Training file ID, or local .jsonl to validate and upload"})
+	validationArg := subCommand.String("v", "validation", &argparse.Options{Required: false, Help: "Validation file ID, or local .jsonl to validate and upload"})
+	epochsArg := subCommand.String("", "epochs", &argparse.Options{Required: false, Help: "Epochs <auto | int>"})
+	batchSizeArg := subCommand.String("", "batch-size", &argparse.Options{Required: false, Help: "Batch size <auto | int>"})
+	lrMultiplierArg := subCommand.String("", "lr-multiplier", &argparse.Options{Required: false, Help: "Learning rate multiplier <auto | float>"})
+	suffixArg := subCommand.String("s", "suffix", &argparse.Options{Required: false, Help: "Suffix for the fine-tuned model name (up to 64 characters)"})
+	seedArg := subCommand.Int("", "seed", &argparse.Options{Required: false, Help: "Seed for reproducible training"})
+	metadataArg := subCommand.StringList("", "meta", &argparse.Options{Required: false, Help: "Set metadata <key>=<value>"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	yesFlag := subCommand.Flag("y", "yes", &argparse.Options{Required: false, Help: "Answer yes to all prompts"})
+	noValidateFlag := subCommand.Flag("", "no-validate", &argparse.Options{Required: false, Help: "Upload local files without validating them"})
+	priceArg := subCommand.Float("", "price", &argparse.Options{Required: false, Help: "Training price in USD per 1M tokens (default from model)"})
+
+	return &CreateCommand{
+		name,
+		desc,
+		subCommand,
+		modelArg,
+		trainingArg,
+		validationArg,
+		epochsArg,
+		batchSizeArg,
+		lrMultiplierArg,
+		suffixArg,
+		seedArg,
+		metadataArg,
+		orgArg,
+		yesFlag,
+		noValidateFlag,
+		priceArg,
+	}
+}
+
+func (c *CreateCommand) Happened() bool {
+
+	return c.command.Happened()
+}
+
+func (c *CreateCommand) Run(key string) error {
+	args := c.command.GetArgs()
+	seedParsed := args[8].GetParsed()
+
+	hyperparameters, epochs, err := c.getHyperparameters()
+
+	if err != nil {
+		return err
+	}
+
+	metadata, err := parseMetadata(*c.metadataArg)
+
+	if err != nil {
+		return err
+	}
+
+	if len(*c.suffixArg) > 64 {
+		errMsg := fmt.Sprintf("Invalid suffix: '%v'. (should be at most 64 characters)", *c.suffixArg)
+		err = errors.New(errMsg)
+		return err
+	}
+
+	localFiles := []string{}
+	for _, fileArg := range []string{*c.trainingArg, *c.validationArg} {
+		if fileArg != "" && isLocalFile(fileArg) {
+			localFiles = append(localFiles, fileArg)
+		}
+	}
+
+	if !*c.noValidateFlag {
+		for _, fileName := range localFiles {
+			report, err := validateFile(fileName, *c.modelArg, epochs, *c.priceArg)
+
+			if err != nil {
+				return err
+			}
+
+			if !report.Valid() {
+				errMsg := fmt.Sprintf("Training file '%v' has %v errors (use --no-validate to upload anyway)", fileName, len(report.Errors))
+				err = errors.New(errMsg)
+				return err
+			}
+		}
+	}
+
+	confirmMsg := fmt.Sprintf("Start fine-tune of %v", *c.modelArg)
+	confirmed, err := tui.Confirm(confirmMsg, *c.yesFlag)
+
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		fmt.Printf("Cancelled.\n")
+		return nil
+	}
+
+	trainingFileID, err := c.fileID(key, *c.trainingArg)
+
+	if err != nil {
+		return err
+	}
+
+	validationFileID := ""
+	if *c.validationArg != "" {
+		validationFileID, err = c.fileID(key, *c.validationArg)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	createdJob := openai.CreatedFineTuningJob{
+		Model:           *c.modelArg,
+		TrainingFile:    trainingFileID,
+		ValidationFile:  validationFileID,
+		Hyperparameters: hyperparameters,
+		Suffix:          *c.suffixArg,
+		Metadata:        metadata,
+	}
+
+	if seedParsed {
+		createdJob.Seed = c.seedArg
+	}
+
+	fmt.Printf("Creating fine-tuning job...\t")
+	job, err := openai.NewFineTuningJob(key, &createdJob, *c.orgArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+	fmt.Printf("Created fine-tuning job: %v (status %v)\n", job.ID, job.Status)
+
+	audit.LogConfirmed("finetune create", *c.orgArg, []string{job.ID}, *c.yesFlag)
+
+	return nil
+}
+
+// fileID uploads a local file for fine-tuning, or passes a file ID through.
+func (c *CreateCommand) fileID(key string, fileArg string) (string, error) {
+	if !isLocalFile(fileArg) {
+		return fileArg, nil
+	}
+
+	content, err := io.BytesInput(fileArg)
+
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Printf("Uploading '%v'...\t", fileArg)
+	fileObject, err := openai.UploadFile(key, filepath.Base(fileArg), content, "fine-tune", *c.orgArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return "", err
+	}
+	fmt.Printf("✓\n")
+	fmt.Printf("Uploaded file: %v\n", fileObject.ID)
+
+	return fileObject.ID, nil
+}
+
+// getHyperparameters returns the hyperparameters to send, if any were given, and
+// the epoch count for estimates (0 for auto).
+func (c *CreateCommand) getHyperparameters() (*openai.Hyperparameters, int, error) {
+	hyperparameters := openai.Hyperparameters{}
+	given := false
+	epochs := 0
+
+	if *c.epochsArg != "" {
+		val, err := parseHyperparameter("epochs", *c.epochsArg, true)
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		hyperparameters.NEpochs = val
+		given = true
+
+		if n, ok := val.(int); ok {
+			epochs = n
+		}
+	}
+
+	if *c.batchSizeArg != "" {
+		val, err := parseHyperparameter("batch size", *c.batchSizeArg, true)
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		hyperparameters.BatchSize = val
+		given = true
+	}
+
+	if *c.lrMultiplierArg != "" {
+		val, err := parseHyperparameter("learning rate multiplier", *c.lrMultiplierArg, false)
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		hyperparameters.LearningRateMultiplier = val
+		given = true
+	}
+
+	if !given {
+		return nil, 0, nil
+	}
+
+	return &hyperparameters, epochs, nil
+}
+
+func parseHyperparameter(name string, val string, isInt bool) (any, error) {
+	if val == "auto" {
+		return val, nil
+	}
+
+	if isInt {
+		n, err := strconv.Atoi(val)
+
+		if err == nil && n > 0 {
+			return n, nil
+		}
+	} else {
+		f, err := strconv.ParseFloat(val, 64)
+
+		if err == nil && f > 0 {
+			return f, nil
+		}
+	}
+
+	errMsg := fmt.Sprintf("Invalid %v: '%v'. (should be 'auto' or a positive number)", name, val)
+	err := errors.New(errMsg)
+	return nil, err
+}
+
+func isLocalFile(fileArg string) bool {
+	_, err := os.Stat(fileArg)
+
+	return err == nil || !strings.HasPrefix(fileArg, "file-")
+}
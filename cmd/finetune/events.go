@@ -0,0 +1,90 @@
+package finetune
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackitaliano/oait/internal/openai"
+
+	"github.com/akamensky/argparse"
+)
+
+type EventsCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	jobArg      *string
+	followFlag  *bool
+	intervalArg *int
+	orgArg      *string
+}
+
+func NewEventsCommand(command *argparse.Command) *EventsCommand {
+	const name = "events"
+	const desc = "Fine-tuning Job Events Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	jobArg := subCommand.String("i", "id", &argparse.Options{Required: true, Help: "Fine-tuning Job ID"})
+	followFlag := subCommand.Flag("", "follow", &argparse.Options{Required: false, Help: "Keep printing new events until the job finishes"})
+	intervalArg := subCommand.Int("", "interval", &argparse.Options{Required: false, Help: "Seconds between checks with --follow", Default: 10})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+
+	return &EventsCommand{
+		name,
+		desc,
+		subCommand,
+		jobArg,
+		followFlag,
+		intervalArg,
+		orgArg,
+	}
+}
+
+func (e *EventsCommand) Happened() bool {
+
+	return e.command.Happened()
+}
+
+func (e *EventsCommand) Run(key string) error {
+	if *e.intervalArg < 1 {
+		errMsg := fmt.Sprintf("Invalid interval: %v. (should be at least 1 second)", *e.intervalArg)
+		err := errors.New(errMsg)
+		return err
+	}
+
+	lastID := ""
+
+	for {
+		// Check the status first, so the final events are printed before stopping
+		job, err := openai.GetFineTuningJob(key, *e.jobArg, *e.orgArg)
+
+		if err != nil {
+			return err
+		}
+
+		events, err := openai.RetrieveFineTuningEventsSince(key, *e.jobArg, lastID, *e.orgArg)
+
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			created := time.Unix(event.CreatedAt, 0).Format(time.DateTime)
+			fmt.Printf("[%v] %-5v %v\n", created, event.Level, event.Message)
+			lastID = event.ID
+		}
+
+		if !*e.followFlag || job.IsDone() {
+			if *e.followFlag {
+				fmt.Printf("Job %v %v.\n", job.ID, job.Status)
+			}
+
+			return nil
+		}
+
+		time.Sleep(time.Duration(*e.intervalArg) * time.Second)
+	}
+}
@@ -0,0 +1,74 @@
+package finetune
+
+import (
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+
+	"github.com/akamensky/argparse"
+)
+
+type GetCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	jobsArg   *[]string
+	inputArg  *string
+	orgArg    *string
+	outputArg *string
+}
+
+func NewGetCommand(command *argparse.Command) *GetCommand {
+	const name = "get"
+	const desc = "Get Fine-tuning Jobs Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	jobsArg := subCommand.StringList("i", "ids", &argparse.Options{Required: false, Help: "List of Fine-tuning Job IDs"})
+	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: false, Help: "Job File Input (of ids)"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Job File Output"})
+
+	return &GetCommand{
+		name,
+		desc,
+		subCommand,
+		jobsArg,
+		inputArg,
+		orgArg,
+		outputArg,
+	}
+}
+
+func (g *GetCommand) Happened() bool {
+
+	return g.command.Happened()
+}
+
+func (g *GetCommand) Run(key string) error {
+	args := g.command.GetArgs()
+
+	fmt.Printf("Retrieving job ids...\t\t")
+	jobIDs, err := getJobIDs(&args, g.jobsArg, g.inputArg, g.name)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Retrieving jobs...\t\t")
+	jobs := openai.RetrieveFineTuningJobs(key, jobIDs, *g.orgArg)
+	fmt.Printf("✓\n")
+
+	jobsOutput, err := io.ListToJSON(jobs)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Outputting jobs... \n\n")
+	return outputJobs(&jobsOutput, *g.outputArg)
+}
@@ -0,0 +1,140 @@
+package finetune
+
+import (
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/filter"
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+
+	"github.com/akamensky/argparse"
+)
+
+type ListCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	statusArg          *[]string
+	timeLTEArg         *float64
+	timeGTArg          *float64
+	nameContainsArg    *[]string
+	nameNotContainsArg *[]string
+	orgArg             *string
+	outputArg          *string
+}
+
+func NewListCommand(command *argparse.Command) *ListCommand {
+	const name = "list"
+	const desc = "List Fine-tuning Jobs Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	statusArg := subCommand.StringList("s", "status", &argparse.Options{Required: false, Help: "Filter by status (e.g. running | succeeded | failed)"})
+	timeLTEArg := subCommand.Float("d", "days", &argparse.Options{Required: false, Help: "Filter by LTE to days"})
+	timeGTArg := subCommand.Float("D", "Days", &argparse.Options{Required: false, Help: "Filter by GT days"})
+	nameContainsArg := subCommand.StringList("n", "name", &argparse.Options{Required: false, Help: "Filter by fine-tuned model containing name"})
+	nameNotContainsArg := subCommand.StringList("N", "Name", &argparse.Options{Required: false, Help: "Filter by fine-tuned model not containing name"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Job File Output"})
+
+	return &ListCommand{
+		name,
+		desc,
+		subCommand,
+		statusArg,
+		timeLTEArg,
+		timeGTArg,
+		nameContainsArg,
+		nameNotContainsArg,
+		orgArg,
+		outputArg,
+	}
+}
+
+func (l *ListCommand) Happened() bool {
+
+	return l.command.Happened()
+}
+
+func (l *ListCommand) Run(key string) error {
+	args := l.command.GetArgs()
+
+	fmt.Printf("Retrieving all jobs...\t\t")
+	jobs, err := openai.RetrieveAllFineTuningJobs(key, *l.orgArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Filtering jobs...\t\t")
+	filtered, err := l.filterJobs(&args, &jobs)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	jobsOutput, err := io.ListToJSON(filtered)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Outputting jobs... \n\n")
+	return outputJobs(&jobsOutput, *l.outputArg)
+}
+
+func (l *ListCommand) filterJobs(args *[]argparse.Arg, jobs *[]openai.FineTuningJob) (*[]openai.FineTuningJob, error) {
+	timeLTEParsed := (*args)[2].GetParsed()
+	timeGTParsed := (*args)[3].GetParsed()
+	nameContainsParsed := (*args)[4].GetParsed()
+	nameNotContainsParsed := (*args)[5].GetParsed()
+
+	filtered := jobs
+	var err error
+
+	if len(*l.statusArg) > 0 {
+		withStatus := []openai.FineTuningJob{}
+
+		for _, job := range *filtered {
+			for _, status := range *l.statusArg {
+				if job.Status == status {
+					withStatus = append(withStatus, job)
+					break
+				}
+			}
+		}
+
+		filtered = &withStatus
+	}
+
+	if timeLTEParsed {
+		filtered, err = filter.DaysLTE(filtered, *l.timeLTEArg)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if timeGTParsed {
+		filtered, err = filter.DaysGT(filtered, *l.timeGTArg)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if nameContainsParsed {
+		filtered = filter.ContainsName(filtered, *l.nameContainsArg)
+	}
+
+	if nameNotContainsParsed {
+		filtered = filter.NotContainsName(filtered, *l.nameNotContainsArg)
+	}
+
+	return filtered, nil
+}
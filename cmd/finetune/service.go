@@ -0,0 +1,172 @@
+package finetune
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/akamensky/argparse"
+
+	"github.com/jackitaliano/oait/internal/io"
+)
+
+type FinetuneService struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	createCommand      *CreateCommand
+	getCommand         *GetCommand
+	listCommand        *ListCommand
+	cancelCommand      *CancelCommand
+	eventsCommand      *EventsCommand
+	checkpointsCommand *CheckpointsCommand
+	validateCommand    *ValidateCommand
+}
+
+func NewService(parser *argparse.Parser) *FinetuneService {
+	const name = "finetune"
+	const desc = "Fine-tuning Tools"
+
+	service := parser.NewCommand(name, desc)
+
+	create := NewCreateCommand(service)
+	get := NewGetCommand(service)
+	list := NewListCommand(service)
+	cancel := NewCancelCommand(service)
+	events := NewEventsCommand(service)
+	checkpoints := NewCheckpointsCommand(service)
+	validate := NewValidateCommand(service)
+
+	return &FinetuneService{
+		name,
+		desc,
+		service,
+		create,
+		get,
+		list,
+		cancel,
+		events,
+		checkpoints,
+		validate,
+	}
+}
+
+func (f *FinetuneService) Run(key string) error {
+
+	if f.createCommand.Happened() {
+		err := f.createCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if f.getCommand.Happened() {
+		err := f.getCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if f.listCommand.Happened() {
+		err := f.listCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if f.cancelCommand.Happened() {
+		err := f.cancelCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if f.eventsCommand.Happened() {
+		err := f.eventsCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if f.checkpointsCommand.Happened() {
+		err := f.checkpointsCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if f.validateCommand.Happened() {
+		err := f.validateCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else {
+		errMsg := fmt.Sprintf("No command given to `%v`\n", f.name)
+		helpMsg := f.command.Help(errMsg)
+		err := errors.New(helpMsg)
+		return err
+	}
+
+	return nil
+}
+
+func getJobIDs(args *[]argparse.Arg, idsArg *[]string, inputArg *string, name string) ([]string, error) {
+	idsParsed := (*args)[1].GetParsed()
+	inputParsed := (*args)[2].GetParsed()
+
+	if idsParsed { // List passed
+		return io.ListInput(*idsArg)
+	}
+
+	if inputParsed { // File input passed
+		return io.FileInput(*inputArg)
+	}
+
+	errMsg := fmt.Sprintf("No input options passed to `%v`\n", name)
+	err := errors.New(errMsg)
+
+	return nil, err
+}
+
+func outputJobs(output *[]byte, outputFile string) error {
+	if outputFile != "" {
+		return io.FileOutput(outputFile, output)
+	}
+
+	fmt.Printf("%v\n", string(*output))
+
+	return nil
+}
+
+func parseMetadata(metadataStrs []string) (map[string]string, error) {
+	if len(metadataStrs) < 1 {
+		return nil, nil
+	}
+
+	metadata := make(map[string]string, len(metadataStrs))
+
+	for _, metadataStr := range metadataStrs {
+		metadataSplit := strings.SplitN(metadataStr, "=", 2)
+
+		if len(metadataSplit) < 2 {
+			errMsg := fmt.Sprintf("invalid metadata: '%s'. (should be '<key>=<value>')", metadataStr)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+
+		metadata[metadataSplit[0]] = metadataSplit[1]
+	}
+
+	return metadata, nil
+}
@@ -0,0 +1,89 @@
+package finetune
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/finetune"
+
+	"github.com/akamensky/argparse"
+)
+
+type ValidateCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	inputArg  *string
+	modelArg  *string
+	epochsArg *int
+	priceArg  *float64
+}
+
+func NewValidateCommand(command *argparse.Command) *ValidateCommand {
+	const name = "validate"
+	const desc = "Validate Training File Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: true, Help: "Chat-format training file (.jsonl)"})
+	modelArg := subCommand.String("m", "model", &argparse.Options{Required: false, Help: "Base model, for the cost estimate", Default: "gpt-4o-mini"})
+	epochsArg := subCommand.Int("", "epochs", &argparse.Options{Required: false, Help: "Epochs, for the cost estimate (default as auto would pick)"})
+	priceArg := subCommand.Float("", "price", &argparse.Options{Required: false, Help: "Training price in USD per 1M tokens (default from model)"})
+
+	return &ValidateCommand{
+		name,
+		desc,
+		subCommand,
+		inputArg,
+		modelArg,
+		epochsArg,
+		priceArg,
+	}
+}
+
+func (v *ValidateCommand) Happened() bool {
+
+	return v.command.Happened()
+}
+
+func (v *ValidateCommand) Run(key string) error {
+	report, err := validateFile(*v.inputArg, *v.modelArg, *v.epochsArg, *v.priceArg)
+
+	if err != nil {
+		return err
+	}
+
+	if !report.Valid() {
+		errMsg := fmt.Sprintf("Training file '%v' has %v errors", *v.inputArg, len(report.Errors))
+		err = errors.New(errMsg)
+		return err
+	}
+
+	return nil
+}
+
+// validateFile validates a training file and prints its report.
+func validateFile(fileName string, model string, epochs int, price float64) (*finetune.Report, error) {
+	fmt.Printf("Validating '%v'...\t", fileName)
+	report, err := finetune.Validate(fileName)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return nil, err
+	}
+
+	if report.Valid() {
+		fmt.Printf("✓\n")
+	} else {
+		fmt.Printf("X\n")
+	}
+
+	if price <= 0 {
+		price, _ = finetune.Price(model)
+	}
+
+	fmt.Printf("\n%v\n\n", report.Format(epochs, price))
+
+	return report, nil
+}
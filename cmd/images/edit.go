@@ -0,0 +1,114 @@
+package images
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+
+	"github.com/akamensky/argparse"
+)
+
+type EditCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	imageArg   *string
+	maskArg    *string
+	promptArg  *string
+	outputArg  *string
+	modelArg   *string
+	sizeArg    *string
+	qualityArg *string
+	numArg     *int
+	formatArg  *string
+	orgArg     *string
+}
+
+func NewEditCommand(command *argparse.Command) *EditCommand {
+	const name = "edit"
+	const desc = "Edit Images Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	imageArg := subCommand.String("i", "image", &argparse.Options{Required: true, Help: "Image File Input (png)"})
+	maskArg := subCommand.String("", "mask", &argparse.Options{Required: false, Help: "Mask File Input (png, transparent where to edit)"})
+	promptArg := subCommand.String("p", "prompt", &argparse.Options{Required: true, Help: "Image Edit Prompt"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Image File Output, numbered per image (default: image.png)"})
+	modelArg := subCommand.String("m", "model", &argparse.Options{Required: false, Help: "OpenAI Model <dall-e-2 | gpt-image-1>"})
+	sizeArg := subCommand.String("s", "size", &argparse.Options{Required: false, Help: "Image size (e.g. 1024x1024)"})
+	qualityArg := subCommand.String("q", "quality", &argparse.Options{Required: false, Help: "Image quality"})
+	numArg := subCommand.Int("n", "num", &argparse.Options{Required: false, Help: "Number of images"})
+	formatArg := subCommand.String("", "format", &argparse.Options{Required: false, Help: "Response format <b64_json | url>"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+
+	return &EditCommand{
+		name,
+		desc,
+		subCommand,
+		imageArg,
+		maskArg,
+		promptArg,
+		outputArg,
+		modelArg,
+		sizeArg,
+		qualityArg,
+		numArg,
+		formatArg,
+		orgArg,
+	}
+}
+
+func (e *EditCommand) Happened() bool {
+	return e.command.Happened()
+}
+
+func (e *EditCommand) Run(key string) error {
+	image, err := imageInput(*e.imageArg)
+
+	if err != nil {
+		return err
+	}
+
+	var mask *openai.FileUpload
+
+	if *e.maskArg != "" {
+		mask, err = imageInput(*e.maskArg)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	imageReq := openai.ImageRequest{
+		Prompt:         *e.promptArg,
+		Model:          *e.modelArg,
+		N:              *e.numArg,
+		Size:           *e.sizeArg,
+		Quality:        *e.qualityArg,
+		ResponseFormat: *e.formatArg,
+	}
+
+	fmt.Printf("Editing image...\t\t")
+	imagesResponse, err := openai.EditImage(key, *image, mask, &imageReq, *e.orgArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	return saveImages(imagesResponse, *e.outputArg)
+}
+
+func imageInput(fileName string) (*openai.FileUpload, error) {
+	content, err := io.BytesInput(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &openai.FileUpload{Name: filepath.Base(fileName), Content: content}, nil
+}
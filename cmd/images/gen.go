@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/jackitaliano/oait/internal/openai"
+
 	"github.com/akamensky/argparse"
 )
 
@@ -12,18 +14,38 @@ type GenCommand struct {
 	desc    string
 	command *argparse.Command
 
-	promptArg *string
-	outputArg *string
+	promptArg  *string
+	outputArg  *string
+	modelArg   *string
+	sizeArg    *string
+	qualityArg *string
+	numArg     *int
+	styleArg   *string
+	formatArg  *string
+	orgArg     *string
 }
 
 func NewGenCommand(command *argparse.Command) *GenCommand {
-	const name = "get"
-	const desc = "Get Thread Tools"
+	return newGenCommand(command, "gen", "Generate Images Tools")
+}
+
+// NewGetCommand is `images get`, the original name of `images gen`, kept as an alias.
+func NewGetCommand(command *argparse.Command) *GenCommand {
+	return newGenCommand(command, "get", "Generate Images Tools (alias of gen)")
+}
 
+func newGenCommand(command *argparse.Command, name string, desc string) *GenCommand {
 	subCommand := command.NewCommand(name, desc)
 
 	promptArg := subCommand.String("p", "prompt", &argparse.Options{Required: false, Help: "Image Generation Prompt"})
-	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Image File Output"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Image File Output, numbered per image (default: image.png)"})
+	modelArg := subCommand.String("m", "model", &argparse.Options{Required: false, Help: "OpenAI Model <dall-e-2 | dall-e-3 | gpt-image-1>"})
+	sizeArg := subCommand.String("s", "size", &argparse.Options{Required: false, Help: "Image size (e.g. 1024x1024)"})
+	qualityArg := subCommand.String("q", "quality", &argparse.Options{Required: false, Help: "Image quality (e.g. standard | hd)"})
+	numArg := subCommand.Int("n", "num", &argparse.Options{Required: false, Help: "Number of images"})
+	styleArg := subCommand.String("", "style", &argparse.Options{Required: false, Help: "Image style <vivid | natural>"})
+	formatArg := subCommand.String("", "format", &argparse.Options{Required: false, Help: "Response format <b64_json | url>"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
 
 	return &GenCommand{
 		name,
@@ -31,6 +53,13 @@ func NewGenCommand(command *argparse.Command) *GenCommand {
 		subCommand,
 		promptArg,
 		outputArg,
+		modelArg,
+		sizeArg,
+		qualityArg,
+		numArg,
+		styleArg,
+		formatArg,
+		orgArg,
 	}
 }
 
@@ -38,16 +67,12 @@ func (g *GenCommand) Happened() bool {
 	return g.command.Happened()
 }
 
-func (g *GenCommand) Run() error {
+func (g *GenCommand) Run(key string) error {
 	args := g.command.GetArgs()
 	promptParsed := args[1].GetParsed()
-	outputParsed := args[2].GetParsed()
 
 	// Input flow
-	if promptParsed {
-		fmt.Println(*g.promptArg)
-
-	} else {
+	if !promptParsed {
 		errMsg := fmt.Sprintf("No input options passed to `%v`\n", g.name)
 		helpMsg := g.command.Help(errMsg)
 
@@ -55,10 +80,25 @@ func (g *GenCommand) Run() error {
 		return err
 	}
 
-	// Output flow
-	if outputParsed {
-		fmt.Println(g.outputArg)
+	imageReq := openai.ImageRequest{
+		Prompt:         *g.promptArg,
+		Model:          *g.modelArg,
+		N:              *g.numArg,
+		Size:           *g.sizeArg,
+		Quality:        *g.qualityArg,
+		Style:          *g.styleArg,
+		ResponseFormat: *g.formatArg,
 	}
 
-	return nil
+	fmt.Printf("Generating images...\t\t")
+	imagesResponse, err := openai.NewImages(key, &imageReq, *g.orgArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	// Output flow
+	return saveImages(imagesResponse, *g.outputArg)
 }
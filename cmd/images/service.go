@@ -1,11 +1,15 @@
 package images
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"os"
 
 	"github.com/akamensky/argparse"
+
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
 )
 
 type ImagesService struct {
@@ -13,7 +17,10 @@ type ImagesService struct {
 	desc    string
 	command *argparse.Command
 
-	genCommand *GenCommand
+	genCommand        *GenCommand
+	getCommand        *GenCommand
+	editCommand       *EditCommand
+	variationsCommand *VariationsCommand
 }
 
 func NewService(parser *argparse.Parser) *ImagesService {
@@ -23,19 +30,49 @@ func NewService(parser *argparse.Parser) *ImagesService {
 	service := parser.NewCommand(name, desc)
 
 	gen := NewGenCommand(service)
+	get := NewGetCommand(service)
+	edit := NewEditCommand(service)
+	variations := NewVariationsCommand(service)
 
 	return &ImagesService{
 		name,
 		desc,
 		service,
 		gen,
+		get,
+		edit,
+		variations,
 	}
 }
 
-func (i *ImagesService) Run() error {
+func (i *ImagesService) Run(key string) error {
 
 	if i.genCommand.Happened() {
-		err := i.genCommand.Run()
+		err := i.genCommand.Run(key)
+
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+
+	} else if i.getCommand.Happened() {
+		err := i.getCommand.Run(key)
+
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+
+	} else if i.editCommand.Happened() {
+		err := i.editCommand.Run(key)
+
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+
+	} else if i.variationsCommand.Happened() {
+		err := i.variationsCommand.Run(key)
 
 		if err != nil {
 			fmt.Println(err.Error())
@@ -51,3 +88,56 @@ func (i *ImagesService) Run() error {
 
 	return nil
 }
+
+// saveImages writes each returned image, decoded from b64_json or downloaded from its
+// URL, to output numbered from 1 (image.png -> image-1.png, image-2.png, ...).
+func saveImages(imagesResponse *openai.ImagesResponse, output string) error {
+	if output == "" {
+		output = "image.png"
+	}
+
+	fmt.Printf("Saving images...\t\t")
+	fileNames := []string{}
+
+	for i, image := range imagesResponse.Data {
+		var content []byte
+		var err error
+
+		if image.B64JSON != "" {
+			content, err = base64.StdEncoding.DecodeString(image.B64JSON)
+		} else if image.URL != "" {
+			content, err = openai.GetImageContent(image.URL)
+		} else {
+			err = errors.New(fmt.Sprintf("Image %v has neither b64_json nor url", i+1))
+		}
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+
+		fileName := io.NumberedFileName(output, i+1)
+		err = io.FileOutput(fileName, &content)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+
+		fileNames = append(fileNames, fileName)
+	}
+	fmt.Printf("✓\n")
+
+	for i, fileName := range fileNames {
+		revisedPrompt := imagesResponse.Data[i].RevisedPrompt
+
+		if revisedPrompt != "" {
+			fmt.Printf("\t%v (revised prompt: %v)\n", fileName, revisedPrompt)
+		} else {
+			fmt.Printf("\t%v\n", fileName)
+		}
+	}
+	fmt.Printf("Saved %v images.\n", len(fileNames))
+
+	return nil
+}
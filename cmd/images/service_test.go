@@ -0,0 +1,75 @@
+package images
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackitaliano/oait/internal/openai"
+)
+
+func TestSaveImagesB64(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "out.png")
+	res := openai.ImagesResponse{Data: []openai.Image{
+		{B64JSON: base64.StdEncoding.EncodeToString([]byte("first"))},
+		{B64JSON: base64.StdEncoding.EncodeToString([]byte("second"))},
+	}}
+
+	err := saveImages(&res, output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertFile(t, filepath.Join(filepath.Dir(output), "out-1.png"), "first")
+	assertFile(t, filepath.Join(filepath.Dir(output), "out-2.png"), "second")
+}
+
+func TestSaveImagesURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("downloaded " + r.URL.Path))
+	}))
+	defer srv.Close()
+
+	output := filepath.Join(t.TempDir(), "out.png")
+	res := openai.ImagesResponse{Data: []openai.Image{
+		{URL: srv.URL + "/a.png"},
+		{URL: srv.URL + "/b.png"},
+	}}
+
+	err := saveImages(&res, output)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertFile(t, filepath.Join(filepath.Dir(output), "out-1.png"), "downloaded /a.png")
+	assertFile(t, filepath.Join(filepath.Dir(output), "out-2.png"), "downloaded /b.png")
+}
+
+func TestSaveImagesNeither(t *testing.T) {
+	res := openai.ImagesResponse{Data: []openai.Image{{}}}
+
+	err := saveImages(&res, filepath.Join(t.TempDir(), "out.png"))
+
+	if err == nil {
+		t.Fatal("saved an image with neither b64_json nor url")
+	}
+}
+
+func assertFile(t *testing.T, fileName string, want string) {
+	t.Helper()
+
+	content, err := os.ReadFile(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != want {
+		t.Errorf("%v = %q, want %q", fileName, content, want)
+	}
+}
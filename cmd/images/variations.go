@@ -0,0 +1,81 @@
+package images
+
+import (
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/openai"
+
+	"github.com/akamensky/argparse"
+)
+
+type VariationsCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	imageArg  *string
+	outputArg *string
+	modelArg  *string
+	sizeArg   *string
+	numArg    *int
+	formatArg *string
+	orgArg    *string
+}
+
+func NewVariationsCommand(command *argparse.Command) *VariationsCommand {
+	const name = "variations"
+	const desc = "Image Variations Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	imageArg := subCommand.String("i", "image", &argparse.Options{Required: true, Help: "Image File Input (png)"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Image File Output, numbered per image (default: image.png)"})
+	modelArg := subCommand.String("m", "model", &argparse.Options{Required: false, Help: "OpenAI Model <dall-e-2>"})
+	sizeArg := subCommand.String("s", "size", &argparse.Options{Required: false, Help: "Image size (e.g. 1024x1024)"})
+	numArg := subCommand.Int("n", "num", &argparse.Options{Required: false, Help: "Number of images"})
+	formatArg := subCommand.String("", "format", &argparse.Options{Required: false, Help: "Response format <b64_json | url>"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+
+	return &VariationsCommand{
+		name,
+		desc,
+		subCommand,
+		imageArg,
+		outputArg,
+		modelArg,
+		sizeArg,
+		numArg,
+		formatArg,
+		orgArg,
+	}
+}
+
+func (v *VariationsCommand) Happened() bool {
+	return v.command.Happened()
+}
+
+func (v *VariationsCommand) Run(key string) error {
+	image, err := imageInput(*v.imageArg)
+
+	if err != nil {
+		return err
+	}
+
+	imageReq := openai.ImageRequest{
+		Model:          *v.modelArg,
+		N:              *v.numArg,
+		Size:           *v.sizeArg,
+		ResponseFormat: *v.formatArg,
+	}
+
+	fmt.Printf("Creating variations...\t\t")
+	imagesResponse, err := openai.NewImageVariations(key, *image, &imageReq, *v.orgArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	return saveImages(imagesResponse, *v.outputArg)
+}
@@ -7,9 +7,21 @@ import (
 	"github.com/akamensky/argparse"
 
 	"github.com/jackitaliano/oait/cmd/assts"
+	"github.com/jackitaliano/oait/cmd/audio"
+	"github.com/jackitaliano/oait/cmd/batch"
+	"github.com/jackitaliano/oait/cmd/browse"
+	"github.com/jackitaliano/oait/cmd/chat"
+	"github.com/jackitaliano/oait/cmd/config"
+	"github.com/jackitaliano/oait/cmd/embeddings"
 	"github.com/jackitaliano/oait/cmd/files"
+	"github.com/jackitaliano/oait/cmd/finetune"
 	"github.com/jackitaliano/oait/cmd/images"
+	"github.com/jackitaliano/oait/cmd/models"
+	"github.com/jackitaliano/oait/cmd/moderate"
+	"github.com/jackitaliano/oait/cmd/sync"
 	"github.com/jackitaliano/oait/cmd/threads"
+	"github.com/jackitaliano/oait/cmd/vault"
+	"github.com/jackitaliano/oait/cmd/whoami"
 )
 
 func main() {
@@ -19,13 +31,37 @@ func main() {
 	parser := argparse.NewParser(progName, progDesc)
 	keyArg := parser.String("k", "key", &argparse.Options{
 		Required: false,
-		Help:     "OpenAI API Key (default to env var 'OPENAI_API_KEY')",
+		Help:     "OpenAI API Key (default to --key-file, env var 'OPENAI_API_KEY', then the profile)",
+	})
+	keyFileArg := parser.String("", "key-file", &argparse.Options{
+		Required: false,
+		Help:     "Read the OpenAI API Key from a file ('-' for stdin)",
+	})
+	profileArg := parser.String("", "profile", &argparse.Options{
+		Required: false,
+		Help:     "Config profile (default to env var 'OAIT_PROFILE', then the current profile)",
+	})
+	projectArg := parser.String("", "project", &argparse.Options{
+		Required: false,
+		Help:     "OpenAI Project ID (default to env var 'OPENAI_PROJECT_ID', then the profile)",
 	})
 
 	threadsService := threads.NewService(parser)
 	filesService := files.NewService(parser)
 	asstsService := assts.NewService(parser)
 	imagesService := images.NewService(parser)
+	chatService := chat.NewService(parser)
+	audioService := audio.NewService(parser)
+	embeddingsService := embeddings.NewService(parser)
+	batchService := batch.NewService(parser)
+	modelsService := models.NewService(parser)
+	finetuneService := finetune.NewService(parser)
+	moderateService := moderate.NewService(parser)
+	configService := config.NewService(parser)
+	vaultService := vault.NewService(parser)
+	whoamiService := whoami.NewService(parser)
+	syncService := sync.NewService(parser)
+	browseService := browse.NewService(parser)
 
 	err := parser.Parse(os.Args)
 	if err != nil {
@@ -33,16 +69,53 @@ func main() {
 		os.Exit(1)
 	}
 
-	if *keyArg == "" {
-		*keyArg = os.Getenv("OPENAI_API_KEY")
-	}
-
 	commands := parser.GetCommands()
 
 	threadsCommand := commands[0]
 	filesCommand := commands[1]
 	asstsCommand := commands[2]
 	imagesCommand := commands[3]
+	chatCommand := commands[4]
+	audioCommand := commands[5]
+	embeddingsCommand := commands[6]
+	batchCommand := commands[7]
+	modelsCommand := commands[8]
+	finetuneCommand := commands[9]
+	moderateCommand := commands[10]
+	configCommand := commands[11]
+	vaultCommand := commands[12]
+	whoamiCommand := commands[13]
+	syncCommand := commands[14]
+	browseCommand := commands[15]
+
+	if configCommand.Happened() { // Works on profiles, so runs before one is applied
+		err := configService.Run(*profileArg)
+
+		if err != nil {
+			fmt.Print(err.Error())
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	if vaultCommand.Happened() { // Manages keys, so runs without one
+		err := vaultService.Run(*keyFileArg)
+
+		if err != nil {
+			fmt.Print(err.Error())
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	err = applyProfile(parser, *profileArg, keyArg, *keyFileArg, *projectArg)
+
+	if err != nil {
+		fmt.Printf("ERROR: %v\n", err.Error())
+		os.Exit(1)
+	}
 
 	if threadsCommand.Happened() {
 		err := threadsService.Run(*keyArg)
@@ -69,7 +142,87 @@ func main() {
 		}
 
 	} else if imagesCommand.Happened() {
-		err := imagesService.Run()
+		err := imagesService.Run(*keyArg)
+
+		if err != nil {
+			fmt.Print(err.Error())
+			os.Exit(1)
+		}
+
+	} else if chatCommand.Happened() {
+		err := chatService.Run(*keyArg)
+
+		if err != nil {
+			fmt.Print(err.Error())
+			os.Exit(1)
+		}
+
+	} else if audioCommand.Happened() {
+		err := audioService.Run(*keyArg)
+
+		if err != nil {
+			fmt.Print(err.Error())
+			os.Exit(1)
+		}
+
+	} else if embeddingsCommand.Happened() {
+		err := embeddingsService.Run(*keyArg)
+
+		if err != nil {
+			fmt.Print(err.Error())
+			os.Exit(1)
+		}
+
+	} else if batchCommand.Happened() {
+		err := batchService.Run(*keyArg)
+
+		if err != nil {
+			fmt.Print(err.Error())
+			os.Exit(1)
+		}
+
+	} else if modelsCommand.Happened() {
+		err := modelsService.Run(*keyArg)
+
+		if err != nil {
+			fmt.Print(err.Error())
+			os.Exit(1)
+		}
+
+	} else if finetuneCommand.Happened() {
+		err := finetuneService.Run(*keyArg)
+
+		if err != nil {
+			fmt.Print(err.Error())
+			os.Exit(1)
+		}
+
+	} else if moderateCommand.Happened() {
+		err := moderateService.Run(*keyArg)
+
+		if err != nil {
+			fmt.Print(err.Error())
+			os.Exit(1)
+		}
+
+	} else if whoamiCommand.Happened() {
+		err := whoamiService.Run(*keyArg)
+
+		if err != nil {
+			fmt.Print(err.Error())
+			os.Exit(1)
+		}
+
+	} else if syncCommand.Happened() {
+		err := syncService.Run(*keyArg)
+
+		if err != nil {
+			fmt.Print(err.Error())
+			os.Exit(1)
+		}
+
+	} else if browseCommand.Happened() {
+		err := browseService.Run(*keyArg)
 
 		if err != nil {
 			fmt.Print(err.Error())
@@ -0,0 +1,158 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+
+	"github.com/akamensky/argparse"
+)
+
+type GetCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	modelsArg          *[]string
+	inputArg           *string
+	orgArg             *string
+	outputArg          *string
+	prettyFlag         *bool
+	timeLTEArg         *float64
+	timeGTArg          *float64
+	nameContainsArg    *[]string
+	nameNotContainsArg *[]string
+	offlineFlag        *bool
+}
+
+func NewGetCommand(command *argparse.Command) *GetCommand {
+	const name = "get"
+	const desc = "Get Models Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	modelsArg := subCommand.StringList("i", "ids", &argparse.Options{Required: false, Help: "List of Model IDs"})
+	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: false, Help: "Model File Input (of ids)"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Model File Output"})
+	prettyFlag := subCommand.Flag("p", "pretty", &argparse.Options{Required: false, Help: "Pretty print models, one per line"})
+	timeLTEArg := subCommand.Float("d", "days", &argparse.Options{Required: false, Help: "Filter by LTE to days"})
+	timeGTArg := subCommand.Float("D", "Days", &argparse.Options{Required: false, Help: "Filter by GT days"})
+	nameContainsArg := subCommand.StringList("n", "name", &argparse.Options{Required: false, Help: "Filter by Model ID containing name"})
+	nameNotContainsArg := subCommand.StringList("N", "Name", &argparse.Options{Required: false, Help: "Filter by Model ID not containing name"})
+	offlineFlag := subCommand.Flag("", "offline", &argparse.Options{Required: false, Help: "Use the cached model list instead of the API"})
+
+	return &GetCommand{
+		name,
+		desc,
+		subCommand,
+		modelsArg,
+		inputArg,
+		orgArg,
+		outputArg,
+		prettyFlag,
+		timeLTEArg,
+		timeGTArg,
+		nameContainsArg,
+		nameNotContainsArg,
+		offlineFlag,
+	}
+}
+
+func (g *GetCommand) Happened() bool {
+
+	return g.command.Happened()
+}
+
+func (g *GetCommand) Run(key string) error {
+	args := g.command.GetArgs()
+
+	fmt.Printf("Retrieving model ids...\t\t")
+	modelIDs, err := g.getModelIDs(&args)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	modelObjects, err := retrieveModels(key, *g.orgArg, *g.offlineFlag)
+
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]openai.Model, len(modelObjects))
+	for _, model := range modelObjects {
+		byID[model.ID] = model
+	}
+
+	found := []openai.Model{}
+	missing := []string{}
+
+	for _, modelID := range modelIDs {
+		model, ok := byID[modelID]
+
+		if !ok {
+			missing = append(missing, modelID)
+			continue
+		}
+
+		found = append(found, model)
+	}
+
+	if len(missing) > 0 {
+		fmt.Printf("Models not available:\n\t%v\n", strings.Join(missing, "\n\t"))
+	}
+
+	filters := modelFilters{
+		timeLTEParsed:   args[6].GetParsed(),
+		timeGTParsed:    args[7].GetParsed(),
+		timeLTE:         *g.timeLTEArg,
+		timeGT:          *g.timeGTArg,
+		nameContains:    *g.nameContainsArg,
+		nameNotContains: *g.nameNotContainsArg,
+	}
+
+	fmt.Printf("Filtering models...\t\t")
+	filteredModels, err := filterModels(filters, &found)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Formatting models output...\t")
+	modelsOutput, err := getModelsOutput(*g.prettyFlag, filteredModels)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Outputting models... \n\n")
+	return outputModels(*g.outputArg, modelsOutput)
+}
+
+func (g *GetCommand) getModelIDs(args *[]argparse.Arg) ([]string, error) {
+	modelsParsed := (*args)[1].GetParsed()
+	inputParsed := (*args)[2].GetParsed()
+
+	if modelsParsed { // List passed
+		return io.ListInput(*g.modelsArg)
+	}
+
+	if inputParsed { // File input passed
+		return io.FileInput(*g.inputArg)
+	}
+
+	errMsg := fmt.Sprintf("No input options passed to `%v`\n", g.name)
+	err := errors.New(errMsg)
+
+	return nil, err
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/akamensky/argparse"
+)
+
+type ListCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	orgArg             *string
+	outputArg          *string
+	prettyFlag         *bool
+	timeLTEArg         *float64
+	timeGTArg          *float64
+	nameContainsArg    *[]string
+	nameNotContainsArg *[]string
+	offlineFlag        *bool
+}
+
+func NewListCommand(command *argparse.Command) *ListCommand {
+	const name = "list"
+	const desc = "List Models Tools"
+
+	subCommand := command.NewCommand(name, desc)
+
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Model File Output"})
+	prettyFlag := subCommand.Flag("p", "pretty", &argparse.Options{Required: false, Help: "Pretty print models, one per line"})
+	timeLTEArg := subCommand.Float("d", "days", &argparse.Options{Required: false, Help: "Filter by LTE to days"})
+	timeGTArg := subCommand.Float("D", "Days", &argparse.Options{Required: false, Help: "Filter by GT days"})
+	nameContainsArg := subCommand.StringList("n", "name", &argparse.Options{Required: false, Help: "Filter by Model ID containing name"})
+	nameNotContainsArg := subCommand.StringList("N", "Name", &argparse.Options{Required: false, Help: "Filter by Model ID not containing name"})
+	offlineFlag := subCommand.Flag("", "offline", &argparse.Options{Required: false, Help: "Use the cached model list instead of the API"})
+
+	return &ListCommand{
+		name,
+		desc,
+		subCommand,
+		orgArg,
+		outputArg,
+		prettyFlag,
+		timeLTEArg,
+		timeGTArg,
+		nameContainsArg,
+		nameNotContainsArg,
+		offlineFlag,
+	}
+}
+
+func (l *ListCommand) Happened() bool {
+
+	return l.command.Happened()
+}
+
+func (l *ListCommand) Run(key string) error {
+	args := l.command.GetArgs()
+
+	modelObjects, err := retrieveModels(key, *l.orgArg, *l.offlineFlag)
+
+	if err != nil {
+		return err
+	}
+
+	filters := modelFilters{
+		timeLTEParsed:   args[4].GetParsed(),
+		timeGTParsed:    args[5].GetParsed(),
+		timeLTE:         *l.timeLTEArg,
+		timeGT:          *l.timeGTArg,
+		nameContains:    *l.nameContainsArg,
+		nameNotContains: *l.nameNotContainsArg,
+	}
+
+	fmt.Printf("Filtering models...\t\t")
+	filteredModels, err := filterModels(filters, &modelObjects)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Formatting models output...\t")
+	modelsOutput, err := getModelsOutput(*l.prettyFlag, filteredModels)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Outputting models... \n\n")
+	return outputModels(*l.outputArg, modelsOutput)
+}
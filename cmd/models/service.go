@@ -0,0 +1,172 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/akamensky/argparse"
+
+	"github.com/jackitaliano/oait/internal/filter"
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/models"
+	"github.com/jackitaliano/oait/internal/openai"
+)
+
+type ModelsService struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	getCommand  *GetCommand
+	listCommand *ListCommand
+}
+
+func NewService(parser *argparse.Parser) *ModelsService {
+	const name = "models"
+	const desc = "Models Tools"
+
+	service := parser.NewCommand(name, desc)
+
+	get := NewGetCommand(service)
+	list := NewListCommand(service)
+
+	return &ModelsService{
+		name,
+		desc,
+		service,
+		get,
+		list,
+	}
+}
+
+func (m *ModelsService) Run(key string) error {
+
+	if m.getCommand.Happened() {
+		err := m.getCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if m.listCommand.Happened() {
+		err := m.listCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else {
+		errMsg := fmt.Sprintf("No command given to `%v`\n", m.name)
+		helpMsg := m.command.Help(errMsg)
+		err := errors.New(helpMsg)
+		return err
+	}
+
+	return nil
+}
+
+// retrieveModels lists models from the API, or from the cache when offline.
+func retrieveModels(key string, orgID string, offline bool) ([]openai.Model, error) {
+	if !offline {
+		fmt.Printf("Retrieving all models...\t")
+		modelObjects, err := models.Fetch(key, orgID)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return nil, err
+		}
+		fmt.Printf("✓\n")
+
+		return modelObjects, nil
+	}
+
+	fmt.Printf("Reading cached models...\t")
+	cache, err := models.Load(key, orgID)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return nil, err
+	}
+	fmt.Printf("✓\n")
+	fmt.Printf("Using cache from %v ago.\n", cache.Age().Round(time.Minute))
+
+	return cache.Models, nil
+}
+
+type modelFilters struct {
+	timeLTEParsed bool
+	timeGTParsed  bool
+
+	timeLTE         float64
+	timeGT          float64
+	nameContains    []string
+	nameNotContains []string
+}
+
+func filterModels(filters modelFilters, modelObjects *[]openai.Model) (*[]openai.Model, error) {
+	filtered := modelObjects
+	var err error
+
+	if filters.timeLTEParsed {
+		filtered, err = filter.DaysLTE(filtered, filters.timeLTE)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if filters.timeGTParsed {
+		filtered, err = filter.DaysGT(filtered, filters.timeGT)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(filters.nameContains) > 0 {
+		filtered = filter.ContainsName(filtered, filters.nameContains)
+	}
+
+	if len(filters.nameNotContains) > 0 {
+		filtered = filter.NotContainsName(filtered, filters.nameNotContains)
+	}
+
+	return filtered, nil
+}
+
+func getModelsOutput(pretty bool, modelObjects *[]openai.Model) (*[]byte, error) {
+	if pretty {
+		lines := []string{}
+
+		for _, model := range *modelObjects {
+			created := time.Unix(model.Created, 0).UTC().Format(time.DateOnly)
+			lines = append(lines, fmt.Sprintf("%-40v %-20v %v", model.ID, model.OwnedBy, created))
+		}
+
+		modelsOutput := []byte(strings.Join(lines, "\n"))
+		return &modelsOutput, nil
+	}
+
+	modelsOutput, err := io.ListToJSON(modelObjects)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &modelsOutput, nil
+}
+
+func outputModels(outputFile string, output *[]byte) error {
+	if outputFile != "" {
+		return io.FileOutput(outputFile, output)
+	}
+
+	fmt.Printf("%v\n", string(*output))
+
+	return nil
+}
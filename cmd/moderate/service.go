@@ -0,0 +1,189 @@
+package moderate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/akamensky/argparse"
+
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/moderate"
+	"github.com/jackitaliano/oait/internal/openai"
+)
+
+type ModerateService struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	threadsArg  *[]string
+	inputArg    *string
+	sessionArg  *string
+	textArg     *[]string
+	stdinFlag   *bool
+	modelArg    *string
+	allFlag     *bool
+	categoryArg *[]string
+	orgArg      *string
+	outputArg   *string
+}
+
+func NewService(parser *argparse.Parser) *ModerateService {
+	const name = "moderate"
+	const desc = "Moderation Tools"
+
+	service := parser.NewCommand(name, desc)
+
+	threadsArg := service.StringList("i", "ids", &argparse.Options{Required: false, Help: "List of Thread IDs"})
+	inputArg := service.String("f", "file-input", &argparse.Options{Required: false, Help: "Thread File Input"})
+	sessionArg := service.String("s", "session", &argparse.Options{Required: false, Help: "Retrieve Threads from session-id"})
+	textArg := service.StringList("p", "text", &argparse.Options{Required: false, Help: "Raw text to moderate"})
+	stdinFlag := service.Flag("", "stdin", &argparse.Options{Required: false, Help: "Read raw text to moderate from stdin"})
+	modelArg := service.String("m", "model", &argparse.Options{Required: false, Help: "Moderation Model", Default: moderate.DefaultModel})
+	allFlag := service.Flag("a", "all", &argparse.Options{Required: false, Help: "Output every message, not only flagged ones"})
+	categoryArg := service.StringList("c", "category", &argparse.Options{Required: false, Help: "Only report these categories (e.g. harassment | self-harm | violence)"})
+	orgArg := service.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	outputArg := service.String("o", "output", &argparse.Options{Required: false, Help: "Moderation File Output"})
+
+	return &ModerateService{
+		name,
+		desc,
+		service,
+		threadsArg,
+		inputArg,
+		sessionArg,
+		textArg,
+		stdinFlag,
+		modelArg,
+		allFlag,
+		categoryArg,
+		orgArg,
+		outputArg,
+	}
+}
+
+func (m *ModerateService) Run(key string) error {
+	err := m.moderate(key)
+
+	if err != nil {
+		fmt.Printf("ERROR: %v\n", err.Error())
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func (m *ModerateService) moderate(key string) error {
+	args := m.command.GetArgs()
+
+	items, numThreads, err := m.getItems(&args, key)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Moderating %v messages...\t", len(items))
+	results, err := moderate.Check(key, items, *m.modelArg, *m.orgArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	flagged := moderate.Flagged(results, *m.categoryArg)
+	output := flagged
+
+	if *m.allFlag {
+		output = results
+	}
+
+	fmt.Printf("Formatting moderation output...\t")
+	moderationOutput, err := io.ListToJSON(&output)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Outputting moderation... \n\n")
+
+	if *m.outputArg != "" {
+		err = io.FileOutput(*m.outputArg, &moderationOutput)
+
+		if err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("%v\n\n", string(moderationOutput))
+	}
+
+	if numThreads > 0 {
+		flaggedThreads := moderate.FlaggedThreads(results, *m.categoryArg)
+		fmt.Printf("Flagged %v of %v messages, in %v of %v threads.\n", len(flagged), len(results), len(flaggedThreads), numThreads)
+	} else {
+		fmt.Printf("Flagged %v of %v texts.\n", len(flagged), len(results))
+	}
+
+	return nil
+}
+
+// getItems returns the texts to moderate: raw text, or every message of the given
+// threads along with how many threads were read.
+func (m *ModerateService) getItems(args *[]argparse.Arg, key string) ([]moderate.Item, int, error) {
+	textParsed := (*args)[4].GetParsed()
+
+	if textParsed {
+		return moderate.FromTexts(*m.textArg), 0, nil
+	}
+
+	if *m.stdinFlag {
+		text, err := io.StdinInput()
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return moderate.FromTexts([]string{text}), 0, nil
+	}
+
+	fmt.Printf("Retrieving thread ids...\t")
+	threadIDs, err := m.getThreadIDs(args)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return nil, 0, err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Retrieving threads...\t\t")
+	threads := openai.RetrieveThreadsMessages(key, threadIDs, *m.orgArg)
+	fmt.Printf("✓\n")
+
+	return moderate.FromThreads(*threads), len(*threads), nil
+}
+
+func (m *ModerateService) getThreadIDs(args *[]argparse.Arg) ([]string, error) {
+	threadsParsed := (*args)[1].GetParsed()
+	inputParsed := (*args)[2].GetParsed()
+	sessionParsed := (*args)[3].GetParsed()
+
+	if threadsParsed { // List passed
+		return io.ListInput(*m.threadsArg)
+	}
+
+	if inputParsed { // File input passed
+		return io.FileInput(*m.inputArg)
+	}
+
+	if sessionParsed {
+		return io.SessionInput(*m.sessionArg, *m.orgArg)
+	}
+
+	errMsg := fmt.Sprintf("No input options passed to `%v`\n", m.name)
+	err := errors.New(errMsg)
+
+	return nil, err
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"os"
+
+	"github.com/akamensky/argparse"
+
+	"github.com/jackitaliano/oait/internal/config"
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/pool"
+	"github.com/jackitaliano/oait/internal/request"
+)
+
+// applyProfile fills in what wasn't given on the command line. Each setting comes
+// from its flag, then its env var, then the selected profile, then the flag default.
+// The key comes from -k, then --key-file, then OPENAI_API_KEY, then the profile.
+func applyProfile(parser *argparse.Parser, profileFlag string, keyArg *string, keyFileFlag string, projectFlag string) error {
+	cfg, err := config.Load()
+
+	if err != nil {
+		return err
+	}
+
+	_, profile, err := cfg.Resolve(profileFlag)
+
+	if err != nil {
+		return err
+	}
+
+	commands := happened(parser.GetCommands())
+
+	if *keyArg == "" && keyFileFlag != "" {
+		*keyArg, err = readKeyFile(keyFileFlag, commands)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if *keyArg == "" {
+		*keyArg = os.Getenv("OPENAI_API_KEY")
+	}
+
+	if *keyArg == "" {
+		if profile.Key == "" && profile.KeyCommand == "" && profile.KeyFile == "-" && readsStdin(commands) {
+			return errStdinKey
+		}
+
+		*keyArg, err = profile.APIKey()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	err = request.SetBaseURL(firstSet(os.Getenv("OPENAI_BASE_URL"), profile.BaseURL))
+
+	if err != nil {
+		return err
+	}
+
+	openai.SetProject(firstSet(projectFlag, os.Getenv("OPENAI_PROJECT_ID"), profile.Project))
+	pool.SetDefaultLimit(profile.Concurrency)
+
+	org := firstSet(os.Getenv("OPENAI_ORG_ID"), profile.Org)
+
+	for _, command := range commands {
+		for _, arg := range command.GetArgs() {
+			if arg.GetParsed() {
+				continue
+			}
+
+			switch result := arg.GetResult().(type) {
+			case *string:
+				if arg.GetLname() == "org" && org != "" {
+					*result = org
+				}
+			case *int:
+				if arg.GetLname() == "concurrency" && profile.Concurrency > 0 {
+					*result = profile.Concurrency
+				}
+			case *bool:
+				if arg.GetLname() == "pretty" && profile.Output == "pretty" {
+					*result = true
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+var errStdinKey = errors.New("Can't read both the key and --stdin input from stdin (use --key-file <file>)")
+
+// readKeyFile reads the key from a file, or from stdin for "-" unless the command
+// takes its own input from stdin.
+func readKeyFile(fileName string, commands []*argparse.Command) (string, error) {
+	if fileName == "-" && readsStdin(commands) {
+		return "", errStdinKey
+	}
+
+	return config.ReadKeyFile(fileName)
+}
+
+func readsStdin(commands []*argparse.Command) bool {
+	for _, command := range commands {
+		for _, arg := range command.GetArgs() {
+			if arg.GetLname() == "stdin" && arg.GetParsed() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// happened is the chain of commands given on the command line, outermost first.
+func happened(commands []*argparse.Command) []*argparse.Command {
+	for _, command := range commands {
+		if command.Happened() {
+			return append([]*argparse.Command{command}, happened(command.GetCommands())...)
+		}
+	}
+
+	return nil
+}
+
+func firstSet(vals ...string) string {
+	for _, val := range vals {
+		if val != "" {
+			return val
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,170 @@
+package sync
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/akamensky/argparse"
+
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/mirror"
+)
+
+type SyncService struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	threadsArg     *[]string
+	inputArg       *string
+	sessionArg     *string
+	onlyArg        *[]string
+	fullFlag       *bool
+	concurrencyArg *int
+	orgArg         *string
+}
+
+var kinds = []string{"threads", "files", "assts"}
+
+func NewService(parser *argparse.Parser) *SyncService {
+	const name = "sync"
+	const desc = "Mirror Threads, Files and Assistants Locally"
+
+	service := parser.NewCommand(name, desc)
+
+	threadsArg := service.StringList("i", "ids", &argparse.Options{Required: false, Help: "List of Thread IDs to add to the mirror"})
+	inputArg := service.String("f", "file-input", &argparse.Options{Required: false, Help: "Thread File Input (of ids to add to the mirror)"})
+	sessionArg := service.String("s", "session", &argparse.Options{Required: false, Help: "Add Threads from session-id to the mirror"})
+	onlyArg := service.StringList("", "only", &argparse.Options{Required: false, Help: "Only sync <threads | files | assts>"})
+	fullFlag := service.Flag("", "full", &argparse.Options{Required: false, Help: "Refetch everything, and record what's been deleted since"})
+	concurrencyArg := service.Int("c", "concurrency", &argparse.Options{Required: false, Help: "Max threads synced at once", Default: 8})
+	orgArg := service.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+
+	return &SyncService{
+		name,
+		desc,
+		service,
+		threadsArg,
+		inputArg,
+		sessionArg,
+		onlyArg,
+		fullFlag,
+		concurrencyArg,
+		orgArg,
+	}
+}
+
+func (s *SyncService) Run(key string) error {
+	err := s.sync(key)
+
+	if err != nil {
+		fmt.Printf("ERROR: %v\n", err.Error())
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func (s *SyncService) sync(key string) error {
+	for _, kind := range *s.onlyArg {
+		if !slices.Contains(kinds, kind) {
+			errMsg := fmt.Sprintf("invalid --only: '%s'. (should be 'threads' | 'files' | 'assts')", kind)
+			err := errors.New(errMsg)
+			return err
+		}
+	}
+
+	newThreadIDs, err := s.getThreadIDs()
+
+	if err != nil {
+		return err
+	}
+
+	m, err := mirror.Open(false)
+
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if s.syncs("files") {
+		fmt.Printf("Syncing files...\t\t")
+		stats, err := m.SyncFiles(key, *s.orgArg, *s.fullFlag)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+		fmt.Printf("✓ (%v)\n", stats.Format())
+	}
+
+	if s.syncs("assts") {
+		fmt.Printf("Syncing assts...\t\t")
+		stats, err := m.SyncAssts(key, *s.orgArg, *s.fullFlag)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+		fmt.Printf("✓ (%v)\n", stats.Format())
+	}
+
+	if s.syncs("threads") {
+		threadIDs, err := m.ThreadIDs(false)
+
+		if err != nil {
+			return err
+		}
+
+		for _, threadID := range newThreadIDs {
+			if !slices.Contains(threadIDs, threadID) {
+				threadIDs = append(threadIDs, threadID)
+			}
+		}
+
+		fmt.Printf("Syncing %v threads...\t\t", len(threadIDs))
+		stats, err := m.SyncThreads(key, threadIDs, *s.orgArg, *s.fullFlag, *s.concurrencyArg)
+
+		if err != nil {
+			fmt.Printf("X\n")
+			return err
+		}
+		fmt.Printf("✓ (%v)\n", stats.Format())
+	}
+
+	fileName, err := mirror.Path()
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nMirror: %v\n", fileName)
+
+	return nil
+}
+
+func (s *SyncService) syncs(kind string) bool {
+	return len(*s.onlyArg) == 0 || slices.Contains(*s.onlyArg, kind)
+}
+
+// getThreadIDs are the threads to add to the mirror; those already in it are synced anyway.
+func (s *SyncService) getThreadIDs() ([]string, error) {
+	args := s.command.GetArgs()
+	threadsParsed := args[1].GetParsed()
+	inputParsed := args[2].GetParsed()
+	sessionParsed := args[3].GetParsed()
+
+	if threadsParsed {
+		return io.ListInput(*s.threadsArg)
+
+	} else if inputParsed {
+		return io.FileInput(*s.inputArg)
+
+	} else if sessionParsed {
+		return io.SessionInput(*s.sessionArg, *s.orgArg)
+	}
+
+	return nil, nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/jackitaliano/oait/internal/audit"
 	"github.com/jackitaliano/oait/internal/io"
 	"github.com/jackitaliano/oait/internal/openai"
 	"github.com/jackitaliano/oait/internal/tui"
@@ -16,11 +17,15 @@ type AddCommand struct {
 	desc    string
 	command *argparse.Command
 
-	threadArg  *string
-	inputArg   *string
-	orgArg     *string
-	messageArg *string
-	roleArg    *string
+	threadsArg   *[]string
+	inputArg     *string
+	orgArg       *string
+	messageArg   *string
+	roleArg      *string
+	stdinFlag    *bool
+	attachArg    *[]string
+	yesFlag      *bool
+	noVerifyFlag *bool
 }
 
 func NewAddCommand(command *argparse.Command) *AddCommand {
@@ -29,21 +34,29 @@ func NewAddCommand(command *argparse.Command) *AddCommand {
 
 	subCommand := command.NewCommand(name, desc)
 
-	threadArg := subCommand.String("i", "ids", &argparse.Options{Required: true, Help: "Thread ID to add message to"})
-	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: false, Help: "Thread File Input"})
+	threadsArg := subCommand.StringList("i", "ids", &argparse.Options{Required: true, Help: "List of Thread IDs to add message(s) to"})
+	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: false, Help: "Message File Input (.json | .jsonl of {role, text, attachments})"})
 	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
 	messageArg := subCommand.String("m", "msg", &argparse.Options{Required: false, Help: "Message text to add"})
 	roleArg := subCommand.String("r", "role", &argparse.Options{Required: false, Help: "Message role to add", Default: "user"})
+	stdinFlag := subCommand.Flag("", "stdin", &argparse.Options{Required: false, Help: "Read message text from stdin (needs --yes or OAIT_ASSUME_YES, as prompts can't read it too)"})
+	attachArg := subCommand.StringList("a", "attach", &argparse.Options{Required: false, Help: "Attach file to message(s) <file_id>:<code_interpreter | file_search>[,...]"})
+	yesFlag := subCommand.Flag("y", "yes", &argparse.Options{Required: false, Help: "Answer yes to all prompts"})
+	noVerifyFlag := subCommand.Flag("", "no-verify", &argparse.Options{Required: false, Help: "Skip verification prompt"})
 
 	return &AddCommand{
 		name,
 		desc,
 		subCommand,
-		threadArg,
+		threadsArg,
 		inputArg,
 		orgArg,
 		messageArg,
 		roleArg,
+		stdinFlag,
+		attachArg,
+		yesFlag,
+		noVerifyFlag,
 	}
 }
 
@@ -54,36 +67,48 @@ func (a *AddCommand) Happened() bool {
 func (a *AddCommand) Run(key string) error {
 	args := a.command.GetArgs()
 
-	threadID, err := a.getThreadID(&args)
+	threadIDs, err := a.getThreadIDs(&args)
 
 	if err != nil {
 		return err
 	}
 
-	message, err := a.createMessage(&args, *threadID)
+	messages, err := a.createMessages(&args)
 
 	if err != nil {
 		return err
 	}
 
-	verify := verifyBeforeAdd()
+	verify, err := verifyBeforeAdd(*a.noVerifyFlag, *a.yesFlag)
+
+	if err != nil {
+		return err
+	}
 
 	if verify {
-		messageJSON, err := io.ObjToJSON(message)
+		messagesJSON, err := io.ListToJSON(&messages)
 
 		if err != nil {
 			return err
 		}
 
-		fmt.Printf("%v\n", string(messageJSON))
+		fmt.Printf("Threads: %v\n", threadIDs)
+		fmt.Printf("%v\n", string(messagesJSON))
 	}
 
-	confirmed := confirmAdd()
+	confirmed, err := confirmAdd(*a.yesFlag)
+
+	if err != nil {
+		return err
+	}
 
 	if confirmed {
-		fmt.Printf("Adding message...\t\t")
-		openai.AddMessage(key, *threadID, message, *a.orgArg)
+		audit.LogConfirmed("threads add", *a.orgArg, threadIDs, *a.yesFlag)
+
+		fmt.Printf("Adding messages...\t\t")
+		numAdded := openai.AddMessages(key, threadIDs, messages, *a.orgArg)
 		fmt.Printf("✓\n")
+		fmt.Printf("Added %v/%v messages.\n", numAdded, len(threadIDs)*len(messages))
 	} else {
 		fmt.Printf("Canceled.\n")
 	}
@@ -91,25 +116,29 @@ func (a *AddCommand) Run(key string) error {
 	return nil
 }
 
-func verifyBeforeAdd() bool {
-	return tui.YesNoLoop("Verify before adding message?")
+func verifyBeforeAdd(noVerify bool, assumeYes bool) (bool, error) {
+	if noVerify {
+		return false, nil
+	}
+
+	return tui.Confirm("Verify before adding message?", assumeYes)
 }
 
-func confirmAdd() bool {
-	return tui.YesNoLoop("Confirm addition")
+func confirmAdd(assumeYes bool) (bool, error) {
+	return tui.Confirm("Confirm addition", assumeYes)
 }
 
-func (a *AddCommand) getThreadID(args *[]argparse.Arg) (*string, error) {
-	threadParsed := (*args)[1].GetParsed()
+func (a *AddCommand) getThreadIDs(args *[]argparse.Arg) ([]string, error) {
+	threadsParsed := (*args)[1].GetParsed()
 
-	if threadParsed { // List passed
-		threadID, err := io.SingleInput(*a.threadArg)
+	if threadsParsed { // List passed
+		threadIDs, err := io.ListInput(*a.threadsArg)
 
 		if err != nil {
 			return nil, err
 		}
 
-		return &threadID, nil
+		return threadIDs, nil
 	}
 
 	errMsg := fmt.Sprintf("No input options passed to `%v`\n", a.name)
@@ -118,17 +147,65 @@ func (a *AddCommand) getThreadID(args *[]argparse.Arg) (*string, error) {
 	return nil, err
 }
 
-func (a *AddCommand) createMessage(args *[]argparse.Arg, threadID string) (*openai.CreatedMessage, error) {
+func (a *AddCommand) createMessages(args *[]argparse.Arg) ([]openai.CreatedMessage, error) {
+	inputParsed := (*args)[2].GetParsed()
 	messageParsed := (*args)[4].GetParsed()
+	stdinParsed := (*args)[6].GetParsed() && *a.stdinFlag
+	attachParsed := (*args)[7].GetParsed()
 
-	if messageParsed {
-		message := io.CreateMessage(*a.messageArg, *a.roleArg)
+	numInputs := 0
+	for _, given := range []bool{inputParsed, messageParsed, stdinParsed} {
+		if given {
+			numInputs += 1
+		}
+	}
 
-		return message, nil
+	if numInputs != 1 {
+		err := errors.New("Must provide exactly one of message (-m), file (-f) or --stdin\n")
+		return nil, err
 	}
 
-	errMsg := fmt.Sprintf("No input options passed to `%v`\n", a.name)
-	err := errors.New(errMsg)
+	if stdinParsed && tui.ConfirmedBy(*a.yesFlag) == "prompt" { // Stdin is the message, not the answers
+		err := errors.New("--stdin needs --yes (or OAIT_ASSUME_YES=1), as the prompts can't read stdin once it's the message\n")
+		return nil, err
+	}
 
-	return nil, err
+	var attachments []openai.Attachment
+	var err error
+
+	if attachParsed {
+		attachments, err = io.AttachmentsInput(*a.attachArg)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if inputParsed { // File input passed; --attach adds to each message's own attachments
+		messages, err := io.MessagesInput(*a.inputArg, *a.roleArg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range messages {
+			messages[i].Attachments = append(messages[i].Attachments, attachments...)
+		}
+
+		return messages, nil
+	}
+
+	text := *a.messageArg
+
+	if stdinParsed {
+		text, err = io.StdinInput()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	message := io.CreateMessage(text, *a.roleArg, attachments)
+
+	return []openai.CreatedMessage{*message}, nil
 }
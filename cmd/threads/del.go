@@ -5,9 +5,13 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/jackitaliano/oait/internal/audit"
+	"github.com/jackitaliano/oait/internal/backup"
 	"github.com/jackitaliano/oait/internal/filter"
 	"github.com/jackitaliano/oait/internal/io"
 	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/plan"
+	"github.com/jackitaliano/oait/internal/protect"
 	"github.com/jackitaliano/oait/internal/tui"
 
 	"github.com/akamensky/argparse"
@@ -31,6 +35,14 @@ type DelCommand struct {
 	contentContainsArg    *[]string
 	contentNotContainsArg *[]string
 	metadataArg           *[]string
+	yesFlag               *bool
+	noVerifyFlag          *bool
+	dryRunFlag            *bool
+	applyPlanArg          *string
+	backupArg             *string
+	noBackupFlag          *bool
+	forceProtectedFlag    *bool
+	maxDeleteArg          *int
 }
 
 func NewDelCommand(command *argparse.Command) *DelCommand {
@@ -52,6 +64,14 @@ func NewDelCommand(command *argparse.Command) *DelCommand {
 	contentContainsArg := subCommand.StringList("c", "content", &argparse.Options{Required: false, Help: "Filter by thread content contains"})
 	contentNotContainsArg := subCommand.StringList("C", "Content", &argparse.Options{Required: false, Help: "Filter by thread content not contains"})
 	metadataArg := subCommand.StringList("m", "meta", &argparse.Options{Required: false, Help: "Filter by thread metadata"})
+	yesFlag := subCommand.Flag("y", "yes", &argparse.Options{Required: false, Help: "Answer yes to all prompts"})
+	noVerifyFlag := subCommand.Flag("", "no-verify", &argparse.Options{Required: false, Help: "Skip verification prompt"})
+	dryRunFlag := subCommand.Flag("", "dry-run", &argparse.Options{Required: false, Help: "Output deletion plan without deleting"})
+	applyPlanArg := subCommand.String("", "apply-plan", &argparse.Options{Required: false, Help: "Delete exactly the threads in plan file (from --dry-run)"})
+	backupArg := subCommand.String("", "backup", &argparse.Options{Required: false, Help: "Backup directory for threads snapshots before deletion (default: <config dir>/oait/backups)"})
+	noBackupFlag := subCommand.Flag("", "no-backup", &argparse.Options{Required: false, Help: "Skip backup before deletion"})
+	forceProtectedFlag := subCommand.Flag("", "force-protected", &argparse.Options{Required: false, Help: "Include threads matched by the protection policy"})
+	maxDeleteArg := subCommand.Int("", "max-delete", &argparse.Options{Required: false, Help: "Abort if more than N threads would be deleted"})
 
 	return &DelCommand{
 		name,
@@ -70,6 +90,14 @@ func NewDelCommand(command *argparse.Command) *DelCommand {
 		contentContainsArg,
 		contentNotContainsArg,
 		metadataArg,
+		yesFlag,
+		noVerifyFlag,
+		dryRunFlag,
+		applyPlanArg,
+		backupArg,
+		noBackupFlag,
+		forceProtectedFlag,
+		maxDeleteArg,
 	}
 }
 
@@ -79,6 +107,11 @@ func (d *DelCommand) Happened() bool {
 
 func (d *DelCommand) Run(key string) error {
 	args := d.command.GetArgs()
+	applyPlanParsed := args[17].GetParsed()
+
+	if applyPlanParsed {
+		return d.applyPlan(key)
+	}
 
 	fmt.Printf("Retrieving thread ids...\t")
 	threadIDs, err := d.getThreadIDs(&args)
@@ -110,9 +143,21 @@ func (d *DelCommand) Run(key string) error {
 	}
 	fmt.Printf("✓\n")
 
-	verify := verifyBeforeDelete()
+	deleteThreadIDs, err := protect.Filter(key, "threads", getThreadIDsFromObjects(filteredThreads), *d.orgArg, *d.forceProtectedFlag)
+
+	if err != nil {
+		return err
+	}
+
+	if *d.dryRunFlag {
+		return d.outputPlan(&args, filteredThreads, deleteThreadIDs)
+	}
+
+	verify, err := verifyBeforeDelete(*d.noVerifyFlag, *d.yesFlag)
 
-	deleteThreadIDs := getThreadIDsFromObjects(filteredThreads)
+	if err != nil {
+		return err
+	}
 
 	if verify {
 		fmt.Printf("Formatting thread output...\t")
@@ -133,12 +178,86 @@ func (d *DelCommand) Run(key string) error {
 		}
 	}
 
-	confirmed := confirmDelete()
+	return d.deleteThreads(key, deleteThreadIDs)
+}
+
+func (d *DelCommand) applyPlan(key string) error {
+	fmt.Printf("Reading plan...\t\t\t")
+	deletePlan, err := io.JSONInput[plan.Plan](*d.applyPlanArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+
+	deleteThreadIDs, err := deletePlan.GetIDs("threads")
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	if *d.orgArg == "" { // Delete from the org the plan was made in
+		*d.orgArg = deletePlan.OrgID
+	}
+
+	deleteThreadIDs, err = protect.Filter(key, "threads", deleteThreadIDs, *d.orgArg, *d.forceProtectedFlag)
+
+	if err != nil {
+		return err
+	}
+
+	verify, err := verifyBeforeDelete(*d.noVerifyFlag, *d.yesFlag)
+
+	if err != nil {
+		return err
+	}
+
+	if verify {
+		planOutput, err := io.ObjToJSON(deletePlan)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Outputting plan... \n\n")
+		fmt.Printf("%v\n", string(planOutput))
+	}
+
+	return d.deleteThreads(key, deleteThreadIDs)
+}
+
+func (d *DelCommand) deleteThreads(key string, deleteThreadIDs []string) error {
+	maxDeleteParsed := d.command.GetArgs()[21].GetParsed()
+
+	if maxDeleteParsed && len(deleteThreadIDs) > *d.maxDeleteArg {
+		errMsg := fmt.Sprintf("Refusing to delete %v threads: more than --max-delete %v", len(deleteThreadIDs), *d.maxDeleteArg)
+		err := errors.New(errMsg)
+		return err
+	}
+
+	confirmed, err := confirmDelete(*d.yesFlag)
+
+	if err != nil {
+		return err
+	}
 
 	if confirmed {
+		if !*d.noBackupFlag {
+			err = backup.Before(key, "threads", deleteThreadIDs, *d.orgArg, *d.backupArg)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		audit.LogConfirmed("threads del", *d.orgArg, deleteThreadIDs, *d.yesFlag)
+
 		fmt.Printf("Deleting threads...\t\t")
-		openai.DeleteThreads(key, deleteThreadIDs, *d.orgArg)
+		numDeleted := openai.DeleteThreads(key, deleteThreadIDs, *d.orgArg)
 		fmt.Printf("✓\n")
+		fmt.Printf("Deleted %v threads.\n", numDeleted)
 	} else {
 		fmt.Printf("Cancelled.\n")
 	}
@@ -146,12 +265,75 @@ func (d *DelCommand) Run(key string) error {
 	return nil
 }
 
-func verifyBeforeDelete() bool {
-	return tui.YesNoLoop("Verify threads before deletion?")
+func (d *DelCommand) outputPlan(args *[]argparse.Arg, filteredThreads *[]openai.Messages, deleteThreadIDs []string) error {
+	fmt.Printf("Formatting plan output...\t")
+	items := plan.KeepIDs(plan.ThreadItems(filteredThreads, d.getReasons(args)), deleteThreadIDs)
+	deletePlan := plan.NewDeletePlan("threads", *d.orgArg, items)
+
+	planOutput, err := io.ObjToJSON(deletePlan)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Outputting plan (dry run, %v threads)... \n\n", len(items))
+	return d.outputThreads(args, &planOutput)
 }
 
-func confirmDelete() bool {
-	return tui.YesNoLoop("Confirm deletion")
+func (d *DelCommand) getReasons(args *[]argparse.Arg) []string {
+	reasons := []string{}
+
+	if (*args)[1].GetParsed() {
+		reasons = append(reasons, "listed in --ids")
+	} else if (*args)[2].GetParsed() {
+		reasons = append(reasons, fmt.Sprintf("listed in '%v'", *d.inputArg))
+	} else if (*args)[3].GetParsed() {
+		reasons = append(reasons, "in session threads")
+	}
+
+	if (*args)[13].GetParsed() {
+		reasons = append(reasons, fmt.Sprintf("metadata equals %v", strings.Join(*d.metadataArg, ", ")))
+	}
+
+	if (*args)[7].GetParsed() {
+		reasons = append(reasons, fmt.Sprintf("created <= %v days ago", *d.timeLTEArg))
+	}
+
+	if (*args)[8].GetParsed() {
+		reasons = append(reasons, fmt.Sprintf("created > %v days ago", *d.timeGTArg))
+	}
+
+	if (*args)[9].GetParsed() {
+		reasons = append(reasons, fmt.Sprintf("length <= %v", *d.lengthLTEArg))
+	}
+
+	if (*args)[10].GetParsed() {
+		reasons = append(reasons, fmt.Sprintf("length > %v", *d.lengthGTArg))
+	}
+
+	for _, content := range *d.contentContainsArg {
+		reasons = append(reasons, fmt.Sprintf("content contains '%v'", content))
+	}
+
+	for _, content := range *d.contentNotContainsArg {
+		reasons = append(reasons, fmt.Sprintf("content not contains '%v'", content))
+	}
+
+	return reasons
+}
+
+func verifyBeforeDelete(noVerify bool, assumeYes bool) (bool, error) {
+	if noVerify {
+		return false, nil
+	}
+
+	return tui.Confirm("Verify threads before deletion?", assumeYes)
+}
+
+func confirmDelete(assumeYes bool) (bool, error) {
+	return tui.Confirm("Confirm deletion", assumeYes)
 }
 
 func (d *DelCommand) getThreadIDs(args *[]argparse.Arg) ([]string, error) {
@@ -298,9 +480,7 @@ func (d *DelCommand) filterThreads(args *[]argparse.Arg, rawThreads *[]openai.Me
 }
 
 func (d *DelCommand) getThreadsOutput(args *[]argparse.Arg, threadIDs []string, filteredThreads *[]openai.Messages) (*[]byte, error) {
-	prettyParsed := (*args)[6].GetParsed()
-
-	if prettyParsed && *(d.prettyFlag) {
+	if *(d.prettyFlag) {
 		parsedThreads := io.ParseThreads(threadIDs, filteredThreads)
 		threadOutput, err := io.ListToJSON(parsedThreads)
 
@@ -7,6 +7,8 @@ import (
 
 	"github.com/jackitaliano/oait/internal/filter"
 	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/mirror"
+	"github.com/jackitaliano/oait/internal/moderate"
 	"github.com/jackitaliano/oait/internal/openai"
 
 	"github.com/akamensky/argparse"
@@ -30,6 +32,10 @@ type GetCommand struct {
 	contentContainsArg    *[]string
 	contentNotContainsArg *[]string
 	metadataArg           *[]string
+	flaggedFlag           *bool
+	flaggedCategoryArg    *[]string
+	offlineFlag           *bool
+	includeDeletedFlag    *bool
 }
 
 func NewGetCommand(command *argparse.Command) *GetCommand {
@@ -51,6 +57,10 @@ func NewGetCommand(command *argparse.Command) *GetCommand {
 	contentContainsArg := subCommand.StringList("c", "content", &argparse.Options{Required: false, Help: "Filter by thread content contains"})
 	contentNotContainsArg := subCommand.StringList("C", "Content", &argparse.Options{Required: false, Help: "Filter by thread content not contains"})
 	metadataArg := subCommand.StringList("m", "meta", &argparse.Options{Required: false, Help: "Filter by thread metadata"})
+	flaggedFlag := subCommand.Flag("", "flagged", &argparse.Options{Required: false, Help: "Filter by threads with a message flagged by moderation"})
+	flaggedCategoryArg := subCommand.StringList("", "flagged-category", &argparse.Options{Required: false, Help: "Filter by threads flagged in these moderation categories"})
+	offlineFlag := subCommand.Flag("", "offline", &argparse.Options{Required: false, Help: "Read threads from the mirror (see `oait sync`); all of them without ids"})
+	includeDeletedFlag := subCommand.Flag("", "include-deleted", &argparse.Options{Required: false, Help: "With --offline and no ids, include threads deleted since mirroring"})
 
 	return &GetCommand{
 		name,
@@ -69,6 +79,10 @@ func NewGetCommand(command *argparse.Command) *GetCommand {
 		contentContainsArg,
 		contentNotContainsArg,
 		metadataArg,
+		flaggedFlag,
+		flaggedCategoryArg,
+		offlineFlag,
+		includeDeletedFlag,
 	}
 }
 
@@ -80,8 +94,20 @@ func (g *GetCommand) Happened() bool {
 func (g *GetCommand) Run(key string) error {
 	args := g.command.GetArgs()
 
+	var m *mirror.Mirror
+
+	if *g.offlineFlag {
+		var err error
+		m, err = mirror.Open(true)
+
+		if err != nil {
+			return err
+		}
+		defer m.Close()
+	}
+
 	fmt.Printf("Retrieving thread ids...\t")
-	threadIDs, err := g.getThreadIDs(&args)
+	threadIDs, err := g.getThreadIDs(&args, m)
 
 	if err != nil {
 		fmt.Printf("X\n")
@@ -90,7 +116,7 @@ func (g *GetCommand) Run(key string) error {
 	fmt.Printf("✓\n")
 
 	fmt.Printf("Filtering thread ids...\t\t")
-	filteredThreadIDs, err := g.filterThreadsIds(&args, key, threadIDs)
+	filteredThreadIDs, err := g.filterThreadsIds(&args, key, m, threadIDs)
 	if err != nil {
 		fmt.Printf("X\n")
 		return err
@@ -98,11 +124,20 @@ func (g *GetCommand) Run(key string) error {
 	fmt.Printf("✓\n")
 
 	fmt.Printf("Retrieving threads...\t\t")
-	rawThreads := openai.RetrieveThreadsMessages(key, filteredThreadIDs, *g.orgArg)
+	rawThreads, missing, err := g.retrieveThreadsMessages(key, m, filteredThreadIDs)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
 	fmt.Printf("✓\n")
 
+	if len(missing) > 0 {
+		fmt.Printf("WARNING: %v threads not in the mirror (see `oait sync`): %v\n", len(missing), strings.Join(missing, " "))
+	}
+
 	fmt.Printf("Filtering threads...\t\t")
-	filteredThreads, err := g.filterThreads(&args, rawThreads)
+	filteredThreads, err := g.filterThreads(&args, key, rawThreads)
 
 	if err != nil {
 		fmt.Printf("X\n")
@@ -129,7 +164,7 @@ func (g *GetCommand) Run(key string) error {
 	return nil
 }
 
-func (g *GetCommand) getThreadIDs(args *[]argparse.Arg) ([]string, error) {
+func (g *GetCommand) getThreadIDs(args *[]argparse.Arg, m *mirror.Mirror) ([]string, error) {
 	threadsParsed := (*args)[1].GetParsed()
 	inputParsed := (*args)[2].GetParsed()
 	sessionParsed := (*args)[3].GetParsed()
@@ -166,13 +201,17 @@ func (g *GetCommand) getThreadIDs(args *[]argparse.Arg) ([]string, error) {
 
 	}
 
+	if m != nil { // Every mirrored thread
+		return m.ThreadIDs(*g.includeDeletedFlag)
+	}
+
 	errMsg := fmt.Sprintf("No input options passed to `%v`\n", g.name)
 	err := errors.New(errMsg)
 
 	return nil, err
 }
 
-func (g *GetCommand) filterThreadsIds(args *[]argparse.Arg, key string, threadIds []string) ([]string, error) {
+func (g *GetCommand) filterThreadsIds(args *[]argparse.Arg, key string, m *mirror.Mirror, threadIds []string) ([]string, error) {
 	metadataParsed := (*args)[13].GetParsed()
 
 	if !metadataParsed {
@@ -182,7 +221,17 @@ func (g *GetCommand) filterThreadsIds(args *[]argparse.Arg, key string, threadId
 	filtered := threadIds
 	var err error
 
-	threads := openai.RetrieveThreads(key, threadIds, *g.orgArg)
+	var threads *[]openai.Thread
+
+	if m != nil {
+		threads, _, err = m.Threads(threadIds)
+
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		threads = openai.RetrieveThreads(key, threadIds, *g.orgArg)
+	}
 
 	if metadataParsed {
 		metadata := make(map[string]string, len(*g.metadataArg))
@@ -216,7 +265,17 @@ func (g *GetCommand) filterThreadsIds(args *[]argparse.Arg, key string, threadId
 	return filtered, nil
 }
 
-func (g *GetCommand) filterThreads(args *[]argparse.Arg, rawThreads *[]openai.Messages) (*[]openai.Messages, error) {
+// retrieveThreadsMessages gets the threads' messages from the API, or from the mirror
+// when there is one, along with the threads it doesn't have.
+func (g *GetCommand) retrieveThreadsMessages(key string, m *mirror.Mirror, threadIDs []string) (*[]openai.Messages, []string, error) {
+	if m == nil {
+		return openai.RetrieveThreadsMessages(key, threadIDs, *g.orgArg), nil, nil
+	}
+
+	return m.ThreadsMessages(threadIDs)
+}
+
+func (g *GetCommand) filterThreads(args *[]argparse.Arg, key string, rawThreads *[]openai.Messages) (*[]openai.Messages, error) {
 	timeLTEParsed := (*args)[7].GetParsed()
 	timeGTParsed := (*args)[8].GetParsed()
 	lengthLTEParsed := (*args)[9].GetParsed()
@@ -269,13 +328,40 @@ func (g *GetCommand) filterThreads(args *[]argparse.Arg, rawThreads *[]openai.Me
 		filtered = filter.NotContainsContent(filtered, *g.contentNotContainsArg)
 	}
 
+	if *g.flaggedFlag || len(*g.flaggedCategoryArg) > 0 {
+		filtered, err = g.filterFlagged(key, filtered)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return filtered, nil
 }
 
-func (g *GetCommand) getThreadsOutput(args *[]argparse.Arg, threadIDs []string, filteredThreads *[]openai.Messages) (*[]byte, error) {
-	prettyParsed := (*args)[6].GetParsed()
+// filterFlagged keeps the threads with a message moderation flags.
+func (g *GetCommand) filterFlagged(key string, threads *[]openai.Messages) (*[]openai.Messages, error) {
+	items := moderate.FromThreads(*threads)
+	results, err := moderate.Check(key, items, moderate.DefaultModel, *g.orgArg)
 
-	if prettyParsed && *(g.prettyFlag) {
+	if err != nil {
+		return nil, err
+	}
+
+	flaggedThreadIDs := moderate.FlaggedThreads(results, *g.flaggedCategoryArg)
+	filtered := []openai.Messages{}
+
+	for _, thread := range *threads {
+		if len(thread.Messages) > 0 && flaggedThreadIDs[thread.Messages[0].ThreadID] {
+			filtered = append(filtered, thread)
+		}
+	}
+
+	return &filtered, nil
+}
+
+func (g *GetCommand) getThreadsOutput(args *[]argparse.Arg, threadIDs []string, filteredThreads *[]openai.Messages) (*[]byte, error) {
+	if *(g.prettyFlag) {
 		parsedThreads := io.ParseThreads(threadIDs, filteredThreads)
 		threadOutput, err := io.ListToJSON(parsedThreads)
 
@@ -0,0 +1,134 @@
+package threads
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jackitaliano/oait/internal/audit"
+	"github.com/jackitaliano/oait/internal/backup"
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/tui"
+
+	"github.com/akamensky/argparse"
+)
+
+type RestoreCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	inputArg  *string
+	orgArg    *string
+	outputArg *string
+	yesFlag   *bool
+}
+
+func NewRestoreCommand(command *argparse.Command) *RestoreCommand {
+	const name = "restore"
+	const desc = "Restore Threads from backup"
+
+	subCommand := command.NewCommand(name, desc)
+
+	inputArg := subCommand.String("f", "file-input", &argparse.Options{Required: true, Help: "Backup directory or snapshot file"})
+	orgArg := subCommand.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "ID mapping File Output"})
+	yesFlag := subCommand.Flag("y", "yes", &argparse.Options{Required: false, Help: "Answer yes to all prompts"})
+
+	return &RestoreCommand{
+		name,
+		desc,
+		subCommand,
+		inputArg,
+		orgArg,
+		outputArg,
+		yesFlag,
+	}
+}
+
+func (r *RestoreCommand) Happened() bool {
+	return r.command.Happened()
+}
+
+func (r *RestoreCommand) Run(key string) error {
+	args := r.command.GetArgs()
+
+	fmt.Printf("Reading backup...\t\t")
+	snapshots, err := backup.Read(*r.inputArg, "threads")
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	confirmMsg := fmt.Sprintf("Confirm restore of %v threads", len(snapshots))
+	confirmed, err := tui.Confirm(confirmMsg, *r.yesFlag)
+
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		fmt.Printf("Cancelled.\n")
+		return nil
+	}
+
+	fmt.Printf("Restoring threads...\t\t")
+	restored := backup.RestoreThreads(key, snapshots, *r.orgArg)
+	fmt.Printf("✓\n")
+
+	oldIDs := []string{}
+	for oldID := range restored {
+		oldIDs = append(oldIDs, oldID)
+	}
+	sort.Strings(oldIDs)
+
+	restoredIDs := []string{}
+	numPartial := 0
+
+	for _, oldID := range oldIDs {
+		res := restored[oldID]
+
+		if res.NewID != "" {
+			restoredIDs = append(restoredIDs, res.NewID)
+		}
+
+		if res.Status == backup.Partial {
+			numPartial += 1
+			fmt.Printf("WARNING: thread %v only partly restored to %v: %v messages failed to post\n", oldID, res.NewID, res.FailedMessages)
+		}
+
+		if res.NonTextSkipped > 0 {
+			fmt.Printf("WARNING: thread %v: %v messages without text (e.g. images) not restored\n", oldID, res.NonTextSkipped)
+		}
+	}
+
+	audit.LogConfirmed("threads restore", *r.orgArg, restoredIDs, *r.yesFlag)
+	fmt.Printf("Restored %v/%v threads fully, %v partly.\n", len(restoredIDs)-numPartial, len(snapshots), numPartial)
+
+	mappingOutput, err := io.ObjToJSON(&restored)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Outputting ID mapping... \n\n")
+	return r.outputMapping(&args, &mappingOutput)
+}
+
+func (r *RestoreCommand) outputMapping(args *[]argparse.Arg, output *[]byte) error {
+	outputParsed := (*args)[3].GetParsed()
+
+	if outputParsed {
+		err := io.FileOutput(*r.outputArg, output)
+
+		if err != nil {
+			return err
+		}
+
+	} else {
+		fmt.Printf("%v\n", string(*output))
+	}
+
+	return nil
+}
@@ -0,0 +1,271 @@
+package threads
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/mirror"
+	"github.com/jackitaliano/oait/internal/search"
+
+	"github.com/akamensky/argparse"
+)
+
+type SearchCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	queryArg    *string
+	inputArg    *[]string
+	roleArg     *[]string
+	limitArg    *int
+	reindexFlag *bool
+	outputArg   *string
+	prettyFlag  *bool
+	formatArg   *string
+}
+
+var searchFormats = []string{"json", "jsonl", "csv", "text"}
+
+func NewSearchCommand(command *argparse.Command) *SearchCommand {
+	const name = "search"
+	const desc = "Search Thread Messages"
+
+	subCommand := command.NewCommand(name, desc)
+
+	queryArg := subCommand.StringPositional(&argparse.Options{Help: "Words, \"quoted phrases\", -excluded words and role:<role> filters"})
+	inputArg := subCommand.StringList("f", "file-input", &argparse.Options{Required: false, Help: "Thread exports (`threads get -o` output) to index, as well as the mirror"})
+	roleArg := subCommand.StringList("", "role", &argparse.Options{Required: false, Help: "Only search messages from <user | assistant>"})
+	limitArg := subCommand.Int("n", "limit", &argparse.Options{Required: false, Help: "Max threads returned", Default: 20})
+	reindexFlag := subCommand.Flag("", "reindex", &argparse.Options{Required: false, Help: "Rebuild the index even if it's up to date"})
+	outputArg := subCommand.String("o", "output", &argparse.Options{Required: false, Help: "Results File Output"})
+	prettyFlag := subCommand.Flag("p", "pretty", &argparse.Options{Required: false, Help: "Pretty print results (same as --format text)"})
+	formatArg := subCommand.String("", "format", &argparse.Options{Required: false, Help: "Results format <json | jsonl | csv | text>", Default: "json"})
+
+	return &SearchCommand{
+		name,
+		desc,
+		subCommand,
+		queryArg,
+		inputArg,
+		roleArg,
+		limitArg,
+		reindexFlag,
+		outputArg,
+		prettyFlag,
+		formatArg,
+	}
+}
+
+func (s *SearchCommand) Happened() bool {
+
+	return s.command.Happened()
+}
+
+func (s *SearchCommand) Run(key string) error {
+	format := *s.formatArg
+
+	if *s.prettyFlag {
+		format = "text"
+	}
+
+	if !slices.Contains(searchFormats, format) {
+		errMsg := fmt.Sprintf("invalid --format: '%s'. (should be 'json' | 'jsonl' | 'csv' | 'text')", format)
+		err := errors.New(errMsg)
+		return err
+	}
+
+	if *s.queryArg == "" {
+		errMsg := fmt.Sprintf("No query passed to `%v`\n", s.name)
+		err := errors.New(errMsg)
+		return err
+	}
+
+	q, err := search.ParseQuery(*s.queryArg)
+
+	if err != nil {
+		return err
+	}
+
+	for _, role := range *s.roleArg {
+		q.Roles = append(q.Roles, strings.ToLower(role))
+	}
+
+	ix, err := s.getIndex()
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Searching %v threads...\t", ix.NumThreads())
+	results := ix.Search(q, *s.limitArg)
+	fmt.Printf("✓ (%v found)\n", len(results))
+
+	fmt.Printf("Formatting results output...\t")
+	output, err := formatResults(results, format)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Outputting results... \n\n")
+
+	if *s.outputArg != "" {
+		return io.FileOutput(*s.outputArg, &output)
+	}
+
+	fmt.Printf("%v\n", string(output))
+
+	return nil
+}
+
+// getIndex loads the index, rebuilding it when asked, when there are new exports
+// to add, or when the mirror or an export has changed since it was built.
+func (s *SearchCommand) getIndex() (*search.Index, error) {
+	fmt.Printf("Loading index...\t\t")
+	ix, err := search.Load()
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return nil, err
+	}
+	fmt.Printf("✓\n")
+
+	sources, err := s.getSources(ix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ix != nil && !*s.reindexFlag && slices.Equal(sources, ix.Sources) && !ix.Stale() {
+		return ix, nil
+	}
+
+	fmt.Printf("Indexing %v sources...\t\t", len(sources))
+	ix, err = search.Rebuild(sources)
+
+	if err == nil {
+		err = ix.Save()
+	}
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return nil, err
+	}
+	fmt.Printf("✓ (%v threads, %v messages)\n", ix.NumThreads(), len(ix.Docs))
+
+	return ix, nil
+}
+
+// getSources are what to index: the mirror if there is one, the exports indexed
+// before (unless they've since been removed) and those passed.
+func (s *SearchCommand) getSources(ix *search.Index) ([]string, error) {
+	mirrorPath, err := mirror.Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	sources := []string{}
+
+	if _, err := os.Stat(mirrorPath); err == nil {
+		sources = append(sources, mirrorPath)
+	}
+
+	exports := []string{}
+
+	if ix != nil {
+		for _, source := range ix.Sources {
+			if source == mirrorPath {
+				continue
+			}
+
+			if _, err := os.Stat(source); err != nil {
+				fmt.Printf("WARNING: export no longer indexed, as it's gone: %v\n", source)
+				continue
+			}
+
+			exports = append(exports, source)
+		}
+	}
+
+	for _, fileName := range *s.inputArg {
+		fileName, err = filepath.Abs(fileName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(exports, fileName) {
+			exports = append(exports, fileName)
+		}
+	}
+
+	sources = append(sources, exports...)
+
+	if len(sources) == 0 {
+		err = errors.New("Nothing to search: run `oait sync` first, or pass thread exports with -f")
+		return nil, err
+	}
+
+	return sources, nil
+}
+
+func formatResults(results []search.Result, format string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	switch format {
+	case "jsonl":
+		encoder := json.NewEncoder(&buf)
+
+		for _, result := range results {
+			err := encoder.Encode(result)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+	case "csv":
+		writer := csv.NewWriter(&buf)
+		writer.Write([]string{"thread_id", "score", "matches", "message_id", "role", "snippet"})
+
+		for _, result := range results {
+			for _, snippet := range result.Snippets {
+				score := fmt.Sprintf("%v", result.Score)
+				matches := fmt.Sprintf("%v", result.Matches)
+				writer.Write([]string{result.ThreadID, score, matches, snippet.MessageID, snippet.Role, snippet.Text})
+			}
+		}
+
+		writer.Flush()
+
+		if err := writer.Error(); err != nil {
+			return nil, err
+		}
+
+	case "text":
+		for i, result := range results {
+			fmt.Fprintf(&buf, "%v. %v\t(score %v, %v matching messages)\n", i+1, result.ThreadID, result.Score, result.Matches)
+
+			for _, snippet := range result.Snippets {
+				fmt.Fprintf(&buf, "\t[%v] %v\n", snippet.Role, snippet.Text)
+			}
+		}
+
+	default:
+		return io.ListToJSON(&results)
+	}
+
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
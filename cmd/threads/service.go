@@ -13,9 +13,11 @@ type ThreadsService struct {
 	desc    string
 	command *argparse.Command
 
-	getCommand *GetCommand
-	delCommand *DelCommand
-	addCommand *AddCommand
+	getCommand     *GetCommand
+	delCommand     *DelCommand
+	addCommand     *AddCommand
+	restoreCommand *RestoreCommand
+	searchCommand  *SearchCommand
 }
 
 func NewService(parser *argparse.Parser) *ThreadsService {
@@ -27,6 +29,8 @@ func NewService(parser *argparse.Parser) *ThreadsService {
 	get := NewGetCommand(service)
 	del := NewDelCommand(service)
 	add := NewAddCommand(service)
+	restore := NewRestoreCommand(service)
+	search := NewSearchCommand(service)
 
 	return &ThreadsService{
 		name,
@@ -35,6 +39,8 @@ func NewService(parser *argparse.Parser) *ThreadsService {
 		get,
 		del,
 		add,
+		restore,
+		search,
 	}
 }
 
@@ -64,6 +70,22 @@ func (t *ThreadsService) Run(key string) error {
 			os.Exit(1)
 		}
 
+	} else if t.restoreCommand.Happened() {
+		err := t.restoreCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v", err.Error())
+			os.Exit(1)
+		}
+
+	} else if t.searchCommand.Happened() {
+		err := t.searchCommand.Run(key)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v", err.Error())
+			os.Exit(1)
+		}
+
 	} else {
 		errMsg := fmt.Sprintf("No command given to `%v`\n", t.name)
 		helpMsg := t.command.Help(errMsg)
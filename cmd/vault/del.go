@@ -0,0 +1,70 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/vault"
+
+	"github.com/akamensky/argparse"
+)
+
+type DelCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	nameArg *string
+}
+
+func NewDelCommand(command *argparse.Command) *DelCommand {
+	const name = "del"
+	const desc = "Delete a Key"
+
+	subCommand := command.NewCommand(name, desc)
+
+	nameArg := subCommand.String("n", "name", &argparse.Options{Required: true, Help: "Key name"})
+
+	return &DelCommand{
+		name,
+		desc,
+		subCommand,
+		nameArg,
+	}
+}
+
+func (d *DelCommand) Happened() bool {
+
+	return d.command.Happened()
+}
+
+func (d *DelCommand) Run() error {
+	keys, passphrase, err := openVault()
+
+	if err != nil {
+		return err
+	}
+
+	_, ok := keys[*d.nameArg]
+
+	if !ok {
+		errMsg := fmt.Sprintf("No key named '%v' in the vault. (see `oait vault list`)", *d.nameArg)
+		err = errors.New(errMsg)
+		return err
+	}
+
+	delete(keys, *d.nameArg)
+
+	fmt.Printf("Encrypting vault...\t\t")
+	err = vault.Save(keys, passphrase)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Deleted key '%v'.\n", *d.nameArg)
+
+	return nil
+}
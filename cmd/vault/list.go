@@ -0,0 +1,53 @@
+package vault
+
+import (
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/config"
+	"github.com/jackitaliano/oait/internal/vault"
+
+	"github.com/akamensky/argparse"
+)
+
+type ListCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+}
+
+func NewListCommand(command *argparse.Command) *ListCommand {
+	const name = "list"
+	const desc = "List Keys (masked)"
+
+	subCommand := command.NewCommand(name, desc)
+
+	return &ListCommand{
+		name,
+		desc,
+		subCommand,
+	}
+}
+
+func (l *ListCommand) Happened() bool {
+
+	return l.command.Happened()
+}
+
+func (l *ListCommand) Run() error {
+	keys, _, err := openVault()
+
+	if err != nil {
+		return err
+	}
+
+	if len(keys) < 1 {
+		fmt.Printf("No keys in the vault. Add one with `oait vault set`.\n")
+		return nil
+	}
+
+	for _, name := range vault.Names(keys) {
+		fmt.Printf("%v\t%v\n", name, config.MaskKey(keys[name]))
+	}
+
+	return nil
+}
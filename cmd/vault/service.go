@@ -0,0 +1,102 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jackitaliano/oait/internal/vault"
+
+	"github.com/akamensky/argparse"
+)
+
+type VaultService struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	setCommand  *SetCommand
+	listCommand *ListCommand
+	delCommand  *DelCommand
+}
+
+func NewService(parser *argparse.Parser) *VaultService {
+	const name = "vault"
+	const desc = "Encrypted Key Vault Tools"
+
+	service := parser.NewCommand(name, desc)
+
+	set := NewSetCommand(service)
+	list := NewListCommand(service)
+	del := NewDelCommand(service)
+
+	return &VaultService{
+		name,
+		desc,
+		service,
+		set,
+		list,
+		del,
+	}
+}
+
+// Run takes the --key-file flag rather than a key: vault commands manage keys.
+func (v *VaultService) Run(keyFileFlag string) error {
+
+	if v.setCommand.Happened() {
+		err := v.setCommand.Run(keyFileFlag)
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if v.listCommand.Happened() {
+		err := v.listCommand.Run()
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else if v.delCommand.Happened() {
+		err := v.delCommand.Run()
+
+		if err != nil {
+			fmt.Printf("ERROR: %v\n", err.Error())
+			os.Exit(1)
+		}
+
+	} else {
+		errMsg := fmt.Sprintf("No command given to `%v`\n", v.name)
+		helpMsg := v.command.Help(errMsg)
+		err := errors.New(helpMsg)
+		return err
+	}
+
+	return nil
+}
+
+// openVault asks for the passphrase (twice when the vault doesn't exist yet) and loads the keys.
+func openVault() (map[string]string, string, error) {
+	fileName, err := vault.Path()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	_, err = os.Stat(fileName)
+	passphrase, err := vault.Passphrase(errors.Is(err, os.ErrNotExist))
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	keys, err := vault.Load(passphrase)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return keys, passphrase, nil
+}
@@ -0,0 +1,82 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/config"
+	"github.com/jackitaliano/oait/internal/vault"
+
+	"github.com/akamensky/argparse"
+)
+
+type SetCommand struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	nameArg *string
+}
+
+func NewSetCommand(command *argparse.Command) *SetCommand {
+	const name = "set"
+	const desc = "Add or Replace a Key"
+
+	subCommand := command.NewCommand(name, desc)
+
+	nameArg := subCommand.String("n", "name", &argparse.Options{Required: true, Help: "Key name (use from a profile with key_vault=<name>)"})
+
+	return &SetCommand{
+		name,
+		desc,
+		subCommand,
+		nameArg,
+	}
+}
+
+func (s *SetCommand) Happened() bool {
+
+	return s.command.Happened()
+}
+
+// Run takes the key from --key-file (or stdin with '-'), else prompts for it without echoing.
+func (s *SetCommand) Run(keyFileFlag string) error {
+	var key string
+	var err error
+
+	if keyFileFlag != "" {
+		key, err = config.ReadKeyFile(keyFileFlag)
+	} else {
+		key, err = vault.ReadSecret("API key: ")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if key == "" {
+		err = errors.New("Empty key")
+		return err
+	}
+
+	keys, passphrase, err := openVault()
+
+	if err != nil {
+		return err
+	}
+
+	keys[*s.nameArg] = key
+
+	fmt.Printf("Encrypting vault...\t\t")
+	err = vault.Save(keys, passphrase)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+
+	fmt.Printf("Set key '%v' (%v).\n", *s.nameArg, config.MaskKey(key))
+
+	return nil
+}
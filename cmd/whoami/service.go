@@ -0,0 +1,93 @@
+package whoami
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/akamensky/argparse"
+
+	"github.com/jackitaliano/oait/internal/config"
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+)
+
+type WhoamiService struct {
+	name    string
+	desc    string
+	command *argparse.Command
+
+	prettyFlag *bool
+	orgArg     *string
+}
+
+type whoami struct {
+	Key string `json:"key"`
+	openai.Identity
+}
+
+func NewService(parser *argparse.Parser) *WhoamiService {
+	const name = "whoami"
+	const desc = "Show the Org, Project and Type of a Key"
+
+	service := parser.NewCommand(name, desc)
+
+	prettyFlag := service.Flag("p", "pretty", &argparse.Options{Required: false, Help: "Pretty print output"})
+	orgArg := service.String("O", "org", &argparse.Options{Required: false, Help: "Set Organization ID"})
+
+	return &WhoamiService{
+		name,
+		desc,
+		service,
+		prettyFlag,
+		orgArg,
+	}
+}
+
+func (w *WhoamiService) Run(key string) error {
+	err := w.whoami(key)
+
+	if err != nil {
+		fmt.Printf("ERROR: %v\n", err.Error())
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func (w *WhoamiService) whoami(key string) error {
+	fmt.Printf("Resolving key...\t\t")
+	identity, err := openai.GetIdentity(key, *w.orgArg)
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n\n")
+
+	if *w.prettyFlag {
+		project := identity.Project
+
+		if identity.Type == openai.AdminKey {
+			project = "(org-wide)"
+		} else if project == "" {
+			project = "(default)"
+		}
+
+		fmt.Printf("key: %v\n", config.MaskKey(key))
+		fmt.Printf("type: %v\n", identity.Type)
+		fmt.Printf("org: %v\n", identity.Org)
+		fmt.Printf("project: %v\n", project)
+
+		return nil
+	}
+
+	output, err := io.ObjToJSON(&whoami{config.MaskKey(key), *identity})
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%v\n", string(output))
+
+	return nil
+}
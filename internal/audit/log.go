@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jackitaliano/oait/internal/tui"
+)
+
+const LogEnv = "OAIT_AUDIT_LOG"
+
+type Entry struct {
+	Time        string   `json:"time"`
+	Command     string   `json:"command"`
+	OrgID       string   `json:"org_id,omitempty"`
+	IDs         []string `json:"ids"`
+	ConfirmedBy string   `json:"confirmed_by"`
+	Bypassed    bool     `json:"confirmation_bypassed"`
+}
+
+// Log appends an entry for a confirmed action to the audit log (OAIT_AUDIT_LOG, or <config dir>/oait/audit.log).
+func Log(command string, orgID string, ids []string, confirmedBy string) error {
+	fileName, err := logPath()
+
+	if err != nil {
+		return err
+	}
+
+	entry := Entry{
+		Time:        time.Now().UTC().Format(time.RFC3339),
+		Command:     command,
+		OrgID:       orgID,
+		IDs:         ids,
+		ConfirmedBy: confirmedBy,
+		Bypassed:    confirmedBy != "prompt",
+	}
+
+	line, err := json.Marshal(entry)
+
+	if err != nil {
+		err = errors.New("JSON Marshal failed with error: " + err.Error())
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(fileName), 0755)
+
+	if err != nil {
+		err = errors.New("Failed to write to audit log: " + fileName + ". Error: " + err.Error())
+		return err
+	}
+
+	file, err := os.OpenFile(fileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+
+	if err != nil {
+		err = errors.New("Failed to write to audit log: " + fileName + ". Error: " + err.Error())
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+
+	if err != nil {
+		err = errors.New("Failed to write to audit log: " + fileName + ". Error: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// LogConfirmed logs an action confirmed by prompt, or by -y/--yes (assumeYes) or the
+// env var, warning rather than failing if it can't.
+func LogConfirmed(command string, orgID string, ids []string, assumeYes bool) {
+	err := Log(command, orgID, ids, tui.ConfirmedBy(assumeYes))
+
+	if err != nil {
+		fmt.Printf("WARNING: %v\n", err)
+	}
+}
+
+func logPath() (string, error) {
+	fileName := os.Getenv(LogEnv)
+
+	if fileName != "" {
+		return fileName, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+
+	if err != nil {
+		err = errors.New("Failed to locate audit log: " + err.Error())
+		return "", err
+	}
+
+	return filepath.Join(configDir, "oait", "audit.log"), nil
+}
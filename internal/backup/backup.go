@@ -0,0 +1,325 @@
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/pool"
+)
+
+type Snapshot struct {
+	Resource  string             `json:"resource"`
+	CreatedAt string             `json:"created_at"`
+	OrgID     string             `json:"org_id,omitempty"`
+	Thread    *openai.Thread     `json:"thread,omitempty"`
+	Messages  []openai.Message   `json:"messages,omitempty"`
+	Assistant *openai.AsstObject `json:"assistant,omitempty"`
+}
+
+type snapshotResult struct {
+	snapshot *Snapshot
+	err      error
+}
+
+// DefaultDir is a new timestamped directory under <config dir>/oait/backups.
+func DefaultDir(resource string) (string, error) {
+	configDir, err := os.UserConfigDir()
+
+	if err != nil {
+		err = errors.New("Failed to locate backup directory: " + err.Error())
+		return "", err
+	}
+
+	dirName := fmt.Sprintf("%v-%v", resource, time.Now().UTC().Format("20060102T150405Z"))
+
+	return filepath.Join(configDir, "oait", "backups", dirName), nil
+}
+
+func snapshotThread(key string, threadID string, orgID string) snapshotResult {
+	thread, err := openai.GetThread(key, threadID, orgID)
+
+	if err != nil {
+		return snapshotResult{nil, err}
+	}
+
+	messages, err := getAllMessages(key, threadID, orgID)
+
+	if err != nil {
+		return snapshotResult{nil, err}
+	}
+
+	snapshot := newSnapshot("threads", orgID)
+	snapshot.Thread = thread
+	snapshot.Messages = messages
+
+	return snapshotResult{snapshot, nil}
+}
+
+// getAllMessages pages through every message of the thread, oldest first, so a
+// snapshot is never silently missing any.
+func getAllMessages(key string, threadID string, orgID string) ([]openai.Message, error) {
+	messages := []openai.Message{}
+	after := ""
+
+	for {
+		page, err := openai.GetThreadMessagesPage(key, threadID, after, orgID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, page.Data...)
+
+		if !page.HasMore {
+			return messages, nil
+		}
+
+		if page.LastID == "" {
+			errMsg := fmt.Sprintf("Failed paging messages of thread: %v (no cursor after %v messages)", threadID, len(messages))
+			err = errors.New(errMsg)
+			return nil, err
+		}
+
+		after = page.LastID
+	}
+}
+
+// SnapshotThreads fetches each thread and its messages, failing if any one can't be fetched.
+func SnapshotThreads(key string, threadIDs []string, orgID string) ([]Snapshot, error) {
+	results := make([]snapshotResult, len(threadIDs))
+
+	pool.Run(len(threadIDs), pool.DefaultLimit(), func(i int) {
+		results[i] = snapshotThread(key, threadIDs[i], orgID)
+	})
+
+	return collectSnapshots(results)
+}
+
+func snapshotAsst(key string, asstID string, orgID string) snapshotResult {
+	asstObject, err := openai.GetAsstObject(key, asstID, orgID)
+
+	if err != nil {
+		return snapshotResult{nil, err}
+	}
+
+	snapshot := newSnapshot("assts", orgID)
+	snapshot.Assistant = asstObject
+
+	return snapshotResult{snapshot, nil}
+}
+
+// SnapshotAssts fetches each assistant, failing if any one can't be fetched.
+func SnapshotAssts(key string, asstIDs []string, orgID string) ([]Snapshot, error) {
+	results := make([]snapshotResult, len(asstIDs))
+
+	pool.Run(len(asstIDs), pool.DefaultLimit(), func(i int) {
+		results[i] = snapshotAsst(key, asstIDs[i], orgID)
+	})
+
+	return collectSnapshots(results)
+}
+
+// Write saves one <id>.json file per snapshot into dir.
+func Write(dir string, snapshots []Snapshot) error {
+	err := os.MkdirAll(dir, 0700)
+
+	if err != nil {
+		err = errors.New("Failed to create backup directory: " + dir + ". Error: " + err.Error())
+		return err
+	}
+
+	for _, snapshot := range snapshots {
+		data, err := json.MarshalIndent(snapshot, "", "  ")
+
+		if err != nil {
+			err = errors.New("JSON Marshal failed with error: " + err.Error())
+			return err
+		}
+
+		fileName := filepath.Join(dir, snapshot.GetID()+".json")
+		err = os.WriteFile(fileName, data, 0600)
+
+		if err != nil {
+			err = errors.New("Failed to write to file: " + fileName)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Before snapshots the threads or assts about to be deleted into dir (or a new
+// DefaultDir if empty), so `restore` can recreate them. Files can't be, so aren't.
+func Before(key string, resource string, ids []string, orgID string, dir string) error {
+	if resource == "files" || len(ids) < 1 {
+		return nil
+	}
+
+	var err error
+
+	if dir == "" {
+		dir, err = DefaultDir(resource)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Backing up %v...\t\t", resource)
+	var snapshots []Snapshot
+
+	if resource == "threads" {
+		snapshots, err = SnapshotThreads(key, ids, orgID)
+	} else {
+		snapshots, err = SnapshotAssts(key, ids, orgID)
+	}
+
+	if err == nil {
+		err = Write(dir, snapshots)
+	}
+
+	if err != nil {
+		fmt.Printf("X\n")
+		return err
+	}
+	fmt.Printf("✓\n")
+	fmt.Printf("Backed up %v %v to '%v'.\n", len(snapshots), resource, dir)
+
+	return nil
+}
+
+// Read loads the snapshots of resource from a backup directory or a single snapshot file.
+func Read(path string, resource string) ([]Snapshot, error) {
+	info, err := os.Stat(path)
+
+	if err != nil {
+		err = errors.New("Failed reading backup: " + path + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	fileNames := []string{path}
+
+	if info.IsDir() {
+		fileNames, err = filepath.Glob(filepath.Join(path, "*.json"))
+
+		if err != nil {
+			return nil, err
+		}
+
+		sort.Strings(fileNames)
+	}
+
+	snapshots := []Snapshot{}
+
+	for _, fileName := range fileNames {
+		data, err := os.ReadFile(fileName)
+
+		if err != nil {
+			err = errors.New("Failed reading file: " + fileName + ". Error: " + err.Error())
+			return nil, err
+		}
+
+		var snapshot Snapshot
+		err = json.Unmarshal(data, &snapshot)
+
+		if err != nil {
+			err = errors.New("Failed parsing snapshot: " + fileName + ". Error: " + err.Error())
+			return nil, err
+		}
+
+		if snapshot.Resource != resource {
+			continue
+		}
+
+		if snapshot.GetID() == "" {
+			err = errors.New("Invalid snapshot (no id): " + fileName)
+			return nil, err
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if len(snapshots) < 1 {
+		errMsg := fmt.Sprintf("No %v snapshots found in '%v'", resource, path)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+func (s Snapshot) GetID() string {
+	if s.Thread != nil {
+		return s.Thread.ID
+	}
+
+	if s.Assistant != nil {
+		return s.Assistant.ID
+	}
+
+	return ""
+}
+
+// GetCreatedMessages returns the thread's messages oldest first, ready to be re-posted,
+// and how many were skipped as they have no text (e.g. only images), which can't be.
+func (s Snapshot) GetCreatedMessages() ([]openai.CreatedMessage, int) {
+	messages := make([]openai.Message, len(s.Messages))
+	copy(messages, s.Messages)
+
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].CreatedAt < messages[j].CreatedAt
+	})
+
+	createdMessages := []openai.CreatedMessage{}
+	nonText := 0
+
+	for _, message := range messages {
+		createdMessage := message.ToCreated()
+
+		if strings.Trim(createdMessage.Content, " \n") == "" { // Non-text content can't be re-posted
+			nonText += 1
+			continue
+		}
+
+		createdMessages = append(createdMessages, *createdMessage)
+	}
+
+	return createdMessages, nonText
+}
+
+func newSnapshot(resource string, orgID string) *Snapshot {
+	return &Snapshot{
+		Resource:  resource,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		OrgID:     orgID,
+	}
+}
+
+func collectSnapshots(results []snapshotResult) ([]Snapshot, error) {
+	snapshots := []Snapshot{}
+	errs := []string{}
+
+	for _, res := range results {
+		if res.err != nil {
+			errs = append(errs, res.err.Error())
+			continue
+		}
+
+		snapshots = append(snapshots, *res.snapshot)
+	}
+
+	if len(errs) > 0 {
+		errMsg := fmt.Sprintf("Failed to snapshot %v/%v objects:\n%v", len(errs), len(results), strings.Join(errs, "\n"))
+		err := errors.New(errMsg)
+		return nil, err
+	}
+
+	return snapshots, nil
+}
@@ -0,0 +1,95 @@
+package backup
+
+import (
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/pool"
+)
+
+const (
+	Restored = "restored"
+	Partial  = "partial"
+	Failed   = "failed"
+)
+
+// Restore is what became of a backed-up object: its new ID ("" if failed), and
+// for threads, how many messages couldn't be re-posted or were skipped as non-text.
+type Restore struct {
+	NewID          string `json:"new_id"`
+	Status         string `json:"status"`
+	FailedMessages int    `json:"failed_messages,omitempty"`
+	NonTextSkipped int    `json:"non_text_skipped,omitempty"`
+}
+
+func restoreThread(key string, snapshot Snapshot, orgID string) Restore {
+	createdThread := openai.CreatedThread{Metadata: snapshot.Thread.Metadata}
+	thread, err := openai.CreateThread(key, &createdThread, orgID)
+
+	if err != nil {
+		fmt.Println(err)
+		return Restore{Status: Failed}
+	}
+
+	createdMessages, nonText := snapshot.GetCreatedMessages()
+	restore := Restore{NewID: thread.ID, Status: Restored, NonTextSkipped: nonText}
+
+	for _, createdMessage := range createdMessages {
+		_, err := openai.PostMessage(key, thread.ID, &createdMessage, orgID)
+
+		if err != nil {
+			fmt.Println(err)
+			restore.FailedMessages += 1
+		}
+	}
+
+	if restore.FailedMessages > 0 {
+		restore.Status = Partial
+	}
+
+	return restore
+}
+
+// RestoreThreads recreates each thread with its metadata and text messages, by old ID.
+// A thread some messages couldn't be posted to is Partial.
+func RestoreThreads(key string, snapshots []Snapshot, orgID string) map[string]Restore {
+	results := make([]Restore, len(snapshots))
+
+	pool.Run(len(snapshots), pool.DefaultLimit(), func(i int) {
+		results[i] = restoreThread(key, snapshots[i], orgID)
+	})
+
+	return collectRestored(snapshots, results)
+}
+
+func restoreAsst(key string, snapshot Snapshot, orgID string) Restore {
+	asstObject, err := openai.CreateAssistant(key, snapshot.Assistant.ToCreated(), orgID)
+
+	if err != nil {
+		fmt.Println(err)
+		return Restore{Status: Failed}
+	}
+
+	return Restore{NewID: asstObject.ID, Status: Restored}
+}
+
+// RestoreAssts recreates each assistant from its config, by old ID.
+func RestoreAssts(key string, snapshots []Snapshot, orgID string) map[string]Restore {
+	results := make([]Restore, len(snapshots))
+
+	pool.Run(len(snapshots), pool.DefaultLimit(), func(i int) {
+		results[i] = restoreAsst(key, snapshots[i], orgID)
+	})
+
+	return collectRestored(snapshots, results)
+}
+
+func collectRestored(snapshots []Snapshot, results []Restore) map[string]Restore {
+	restored := make(map[string]Restore, len(results))
+
+	for i, res := range results {
+		restored[snapshots[i].GetID()] = res
+	}
+
+	return restored
+}
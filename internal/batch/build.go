@@ -0,0 +1,291 @@
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// MaxRequests is the most requests the Batch API accepts in one input file.
+const MaxRequests = 50000
+
+type Row map[string]any
+
+type Request struct {
+	CustomID string          `json:"custom_id"`
+	Method   string          `json:"method"`
+	URL      string          `json:"url"`
+	Body     json.RawMessage `json:"body"`
+}
+
+var placeholder = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+// RowsInput reads a dataset of .jsonl objects, or a .csv file with a header row.
+func RowsInput(fileName string) ([]Row, error) {
+	file, err := os.Open(fileName)
+
+	if err != nil {
+		err = errors.New("Failed reading input: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+	defer file.Close()
+
+	if strings.HasSuffix(fileName, ".csv") {
+		return csvRows(file, fileName)
+	}
+
+	if !strings.HasSuffix(fileName, ".jsonl") {
+		err = errors.New("Invalid input: " + fileName + ". (should be .jsonl | .csv)")
+		return nil, err
+	}
+
+	rows := []Row{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum += 1
+		line := bytes.TrimSpace(scanner.Bytes())
+
+		if len(line) == 0 {
+			continue
+		}
+
+		var row Row
+		err := json.Unmarshal(line, &row)
+
+		if err != nil {
+			errMsg := fmt.Sprintf("Failed parsing input: %v line %v. Error: %v", fileName, lineNum, err)
+			err = errors.New(errMsg)
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	err = scanner.Err()
+
+	if err != nil {
+		err = errors.New("Failed reading input: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func csvRows(file *os.File, fileName string) ([]Row, error) {
+	records, err := csv.NewReader(file).ReadAll()
+
+	if err != nil {
+		err = errors.New("Failed parsing input: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	rows := []Row{}
+
+	if len(records) < 1 {
+		return rows, nil
+	}
+
+	header := records[0]
+
+	for _, record := range records[1:] {
+		row := make(Row, len(header))
+
+		for i, column := range header {
+			row[column] = record[i]
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// RowIDs gives each row its custom_id: the value of idField, or row-<n> when no
+// field is given. IDs must be unique, as results are matched back by them.
+func RowIDs(rows []Row, idField string) ([]string, error) {
+	ids := make([]string, len(rows))
+	seen := make(map[string]bool, len(rows))
+
+	for i, row := range rows {
+		if idField == "" {
+			ids[i] = fmt.Sprintf("row-%d", i+1)
+			continue
+		}
+
+		val, ok := row[idField]
+
+		if !ok || val == nil || val == "" {
+			errMsg := fmt.Sprintf("Row %v has no '%v' field", i+1, idField)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+
+		id := fmt.Sprint(val)
+
+		if seen[id] {
+			errMsg := fmt.Sprintf("Duplicate id '%v' in row %v", id, i+1)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+		seen[id] = true
+
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// Build renders the request body template once per row and returns the batch input
+// file as JSONL. A string that is exactly {{field}} takes the row value as is (so
+// numbers and objects keep their type); anywhere else {{field}} is replaced as text.
+func Build(template []byte, rows []Row, ids []string, endpoint string) ([]byte, error) {
+	if len(rows) > MaxRequests {
+		errMsg := fmt.Sprintf("Too many requests: %v. (max %v per batch)", len(rows), MaxRequests)
+		err := errors.New(errMsg)
+		return nil, err
+	}
+
+	var tmpl any
+	err := json.Unmarshal(template, &tmpl)
+
+	if err != nil {
+		err = errors.New("Failed parsing template. Error: " + err.Error())
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	for i, row := range rows {
+		body, err := fill(tmpl, row)
+
+		if err != nil {
+			errMsg := fmt.Sprintf("Failed filling template for row %v. Error: %v", i+1, err)
+			err = errors.New(errMsg)
+			return nil, err
+		}
+
+		bodyJSON, err := json.Marshal(body)
+
+		if err != nil {
+			return nil, err
+		}
+
+		line, err := json.Marshal(Request{CustomID: ids[i], Method: "POST", URL: endpoint, Body: bodyJSON})
+
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes(), nil
+}
+
+func fill(tmpl any, row Row) (any, error) {
+	switch val := tmpl.(type) {
+	case map[string]any:
+		filled := make(map[string]any, len(val))
+
+		for k, v := range val {
+			f, err := fill(v, row)
+
+			if err != nil {
+				return nil, err
+			}
+
+			filled[k] = f
+		}
+
+		return filled, nil
+
+	case []any:
+		filled := make([]any, len(val))
+
+		for i, v := range val {
+			f, err := fill(v, row)
+
+			if err != nil {
+				return nil, err
+			}
+
+			filled[i] = f
+		}
+
+		return filled, nil
+
+	case string:
+		if match := placeholder.FindStringSubmatch(val); match != nil && match[0] == val {
+			return lookup(row, match[1])
+		}
+
+		var err error
+		filled := placeholder.ReplaceAllStringFunc(val, func(s string) string {
+			field := placeholder.FindStringSubmatch(s)[1]
+			v, lookupErr := lookup(row, field)
+
+			if lookupErr != nil {
+				err = lookupErr
+				return s
+			}
+
+			if str, ok := v.(string); ok {
+				return str
+			}
+
+			j, _ := json.Marshal(v)
+			return string(j)
+		})
+
+		return filled, err
+	}
+
+	return tmpl, nil
+}
+
+func lookup(row Row, field string) (any, error) {
+	val, ok := row[field]
+
+	if !ok {
+		err := errors.New("missing field '" + field + "'")
+		return nil, err
+	}
+
+	return val, nil
+}
+
+// ParseRequests reads a batch input file back into its requests.
+func ParseRequests(data []byte) ([]Request, error) {
+	requests := []Request{}
+
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+
+		if len(line) == 0 {
+			continue
+		}
+
+		var request Request
+		err := json.Unmarshal(line, &request)
+
+		if err != nil {
+			errMsg := fmt.Sprintf("Failed parsing batch input line %v. Error: %v", i+1, err)
+			err = errors.New(errMsg)
+			return nil, err
+		}
+
+		requests = append(requests, request)
+	}
+
+	return requests, nil
+}
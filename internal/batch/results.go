@@ -0,0 +1,116 @@
+package batch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Output is one line of a batch output or error file.
+type Output struct {
+	ID       string          `json:"id"`
+	CustomID string          `json:"custom_id"`
+	Response *OutputResponse `json:"response"`
+	Error    json.RawMessage `json:"error,omitempty"`
+}
+
+type OutputResponse struct {
+	StatusCode int             `json:"status_code"`
+	RequestID  string          `json:"request_id"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// Result is an input joined to its batch outcome.
+type Result struct {
+	CustomID   string          `json:"custom_id"`
+	Input      any             `json:"input"`
+	StatusCode int             `json:"status_code,omitempty"`
+	Response   json.RawMessage `json:"response,omitempty"`
+	Error      json.RawMessage `json:"error,omitempty"`
+}
+
+type Summary struct {
+	Succeeded int
+	Failed    int
+	Missing   int
+}
+
+// Join matches the lines of the output and error files to the inputs by custom_id,
+// keeping input order. Inputs with no line in either file are kept, marked missing.
+func Join(ids []string, inputs []any, files ...[]byte) ([]Result, *Summary, error) {
+	outputs := make(map[string]Output)
+
+	for _, data := range files {
+		for i, line := range bytes.Split(data, []byte("\n")) {
+			line = bytes.TrimSpace(line)
+
+			if len(line) == 0 {
+				continue
+			}
+
+			var output Output
+			err := json.Unmarshal(line, &output)
+
+			if err != nil {
+				errMsg := fmt.Sprintf("Failed parsing batch result line %v. Error: %v", i+1, err)
+				err = errors.New(errMsg)
+				return nil, nil, err
+			}
+
+			outputs[output.CustomID] = output
+		}
+	}
+
+	results := make([]Result, len(ids))
+	summary := Summary{}
+
+	for i, id := range ids {
+		result := Result{CustomID: id, Input: inputs[i]}
+		output, ok := outputs[id]
+
+		if !ok {
+			result.Error = json.RawMessage(`{"message":"no result in batch output"}`)
+			summary.Missing += 1
+			results[i] = result
+			continue
+		}
+
+		if output.Response != nil {
+			result.StatusCode = output.Response.StatusCode
+			result.Response = output.Response.Body
+		}
+
+		if len(output.Error) > 0 && string(output.Error) != "null" {
+			result.Error = output.Error
+		}
+
+		if result.Error == nil && result.StatusCode == 200 {
+			summary.Succeeded += 1
+		} else {
+			summary.Failed += 1
+		}
+
+		results[i] = result
+	}
+
+	return results, &summary, nil
+}
+
+// ToJSONL writes one result per line.
+func ToJSONL(results []Result) ([]byte, error) {
+	var buf bytes.Buffer
+
+	for _, result := range results {
+		line, err := json.Marshal(result)
+
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes(), nil
+}
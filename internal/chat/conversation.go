@@ -0,0 +1,88 @@
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+)
+
+// Conversation is a JSON or YAML file holding either a bare array of chat messages
+// or {"model": ..., "messages": [...]}. It's saved back in the form it was read.
+type Conversation struct {
+	Model    string               `json:"model,omitempty" yaml:"model,omitempty"`
+	Messages []openai.ChatMessage `json:"messages" yaml:"messages"`
+
+	bare bool
+}
+
+// Load reads a conversation file. A file that doesn't exist yet is an empty conversation.
+func Load(fileName string) (*Conversation, error) {
+	if !isYAML(fileName) && !strings.HasSuffix(fileName, ".json") {
+		err := errors.New("Invalid conversation file: " + fileName + ". Must be .json, .yaml or .yml.")
+		return nil, err
+	}
+
+	_, err := os.Stat(fileName)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return &Conversation{Messages: []openai.ChatMessage{}, bare: true}, nil
+	}
+
+	var data *json.RawMessage
+
+	if isYAML(fileName) {
+		data, err = io.YAMLInput[json.RawMessage](fileName)
+	} else {
+		data, err = io.JSONInput[json.RawMessage](fileName)
+	}
+
+	if err != nil {
+		err = errors.New("Failed loading conversation: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	var conversation Conversation
+
+	if strings.HasPrefix(strings.TrimSpace(string(*data)), "[") {
+		conversation.bare = true
+		err = json.Unmarshal(*data, &conversation.Messages)
+	} else {
+		err = json.Unmarshal(*data, &conversation)
+	}
+
+	if err != nil {
+		err = errors.New("Failed parsing conversation: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	return &conversation, nil
+}
+
+func (c *Conversation) Save(fileName string) error {
+	var output []byte
+	var err error
+
+	if c.bare && isYAML(fileName) {
+		output, err = io.ObjToYAML(&c.Messages)
+	} else if c.bare {
+		output, err = io.ListToJSON(&c.Messages)
+	} else if isYAML(fileName) {
+		output, err = io.ObjToYAML(c)
+	} else {
+		output, err = io.ObjToJSON(c)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return io.FileOutput(fileName, &output)
+}
+
+func isYAML(fileName string) bool {
+	return strings.HasSuffix(fileName, ".yaml") || strings.HasSuffix(fileName, ".yml")
+}
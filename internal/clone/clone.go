@@ -0,0 +1,274 @@
+package clone
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackitaliano/oait/internal/openai"
+)
+
+type Endpoint struct {
+	Key   string
+	OrgID string
+}
+
+type Report struct {
+	FromOrg      string    `json:"from_org,omitempty"`
+	ToOrg        string    `json:"to_org,omitempty"`
+	CreatedAt    string    `json:"created_at"`
+	Assistants   []Mapping `json:"assistants"`
+	Files        []Mapping `json:"files"`
+	VectorStores []Mapping `json:"vector_stores"`
+}
+
+type Mapping struct {
+	OldID string `json:"old_id"`
+	NewID string `json:"new_id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type cloner struct {
+	from   Endpoint
+	to     Endpoint
+	copied map[string]Mapping // Old file/vector store ID to its copy, so shared resources are copied once
+	report *Report
+}
+
+// Assts recreates each assistant at to, first copying the files and rebuilding the
+// vector stores behind its tool resources and rewriting their IDs. An assistant whose
+// resources can't all be copied isn't created.
+func Assts(from Endpoint, to Endpoint, asstObjects []openai.AsstObject) *Report {
+	c := cloner{
+		from:   from,
+		to:     to,
+		copied: make(map[string]Mapping),
+		report: &Report{
+			FromOrg:      from.OrgID,
+			ToOrg:        to.OrgID,
+			CreatedAt:    time.Now().UTC().Format(time.RFC3339),
+			Assistants:   []Mapping{},
+			Files:        []Mapping{},
+			VectorStores: []Mapping{},
+		},
+	}
+
+	for _, asstObject := range asstObjects {
+		mapping := Mapping{OldID: asstObject.ID, Name: asstObject.Name}
+
+		toolResources, err := c.toolResources(asstObject.ToolResources)
+
+		if err != nil {
+			mapping.Error = err.Error()
+			c.report.Assistants = append(c.report.Assistants, mapping)
+			continue
+		}
+
+		createdAsst := asstObject.ToCreated()
+		createdAsst.ToolResources = toolResources
+
+		newAsstObject, err := openai.NewAssistant(c.to.Key, createdAsst, c.to.OrgID)
+
+		if err != nil {
+			mapping.Error = err.Error()
+		} else {
+			mapping.NewID = newAsstObject.ID
+		}
+
+		c.report.Assistants = append(c.report.Assistants, mapping)
+	}
+
+	return c.report
+}
+
+func (c *cloner) toolResources(toolResources map[string]map[string][]string) (map[string]map[string][]string, error) {
+	if toolResources == nil {
+		return nil, nil
+	}
+
+	rewritten := make(map[string]map[string][]string, len(toolResources))
+
+	for resource, resourceIDs := range toolResources {
+		rewritten[resource] = make(map[string][]string, len(resourceIDs))
+
+		for idKey, ids := range resourceIDs {
+			newIDs := make([]string, len(ids))
+
+			for i, id := range ids {
+				var mapping Mapping
+
+				switch idKey {
+				case "file_ids":
+					mapping = c.copyFile(id)
+				case "vector_store_ids":
+					mapping = c.copyVectorStore(id)
+				default:
+					errMsg := fmt.Sprintf("Unsupported tool resource '%v.%v'", resource, idKey)
+					err := errors.New(errMsg)
+					return nil, err
+				}
+
+				if mapping.Error != "" {
+					errMsg := fmt.Sprintf("Failed copying %v: %v", id, mapping.Error)
+					err := errors.New(errMsg)
+					return nil, err
+				}
+
+				newIDs[i] = mapping.NewID
+			}
+
+			rewritten[resource][idKey] = newIDs
+		}
+	}
+
+	return rewritten, nil
+}
+
+func (c *cloner) copyFile(fileID string) Mapping {
+	mapping, ok := c.copied[fileID]
+
+	if ok {
+		return mapping
+	}
+
+	mapping = Mapping{OldID: fileID}
+	fileObject, err := openai.GetFileObject(c.from.Key, fileID, c.from.OrgID)
+
+	if err == nil {
+		mapping.Name = fileObject.Filename
+
+		var content []byte
+		content, err = openai.GetFileContent(c.from.Key, fileID, c.from.OrgID)
+
+		if err == nil {
+			var newFileObject *openai.FileObject
+			newFileObject, err = openai.UploadFile(c.to.Key, fileObject.Filename, content, fileObject.Purpose, c.to.OrgID)
+
+			if err == nil {
+				mapping.NewID = newFileObject.ID
+			}
+		}
+	}
+
+	if err != nil {
+		mapping.Error = err.Error()
+	}
+
+	c.copied[fileID] = mapping
+	c.report.Files = append(c.report.Files, mapping)
+
+	return mapping
+}
+
+func (c *cloner) copyVectorStore(vectorStoreID string) Mapping {
+	mapping, ok := c.copied[vectorStoreID]
+
+	if ok {
+		return mapping
+	}
+
+	mapping = Mapping{OldID: vectorStoreID}
+	err := c.rebuildVectorStore(&mapping)
+
+	if err != nil {
+		mapping.Error = err.Error()
+	}
+
+	c.copied[vectorStoreID] = mapping
+	c.report.VectorStores = append(c.report.VectorStores, mapping)
+
+	return mapping
+}
+
+func (c *cloner) rebuildVectorStore(mapping *Mapping) error {
+	vectorStore, err := openai.GetVectorStore(c.from.Key, mapping.OldID, c.from.OrgID)
+
+	if err != nil {
+		return err
+	}
+
+	mapping.Name = vectorStore.Name
+
+	fileIDs, err := openai.RetrieveAllVectorStoreFileIDs(c.from.Key, mapping.OldID, c.from.OrgID)
+
+	if err != nil {
+		return err
+	}
+
+	newFileIDs := make([]string, len(fileIDs))
+
+	for i, fileID := range fileIDs {
+		fileMapping := c.copyFile(fileID)
+
+		if fileMapping.Error != "" {
+			errMsg := fmt.Sprintf("Failed copying file %v: %v", fileID, fileMapping.Error)
+			err := errors.New(errMsg)
+			return err
+		}
+
+		newFileIDs[i] = fileMapping.NewID
+	}
+
+	createdVectorStore := openai.CreatedVectorStore{
+		Name:     vectorStore.Name,
+		FileIDs:  newFileIDs,
+		Metadata: vectorStore.Metadata,
+	}
+
+	newVectorStore, err := openai.NewVectorStore(c.to.Key, &createdVectorStore, c.to.OrgID)
+
+	if err != nil {
+		return err
+	}
+
+	mapping.NewID = newVectorStore.ID
+
+	return nil
+}
+
+// Format renders the report as old -> new ID lines per resource, failures marked X.
+func (r Report) Format() string {
+	lines := []string{}
+
+	sections := []struct {
+		title    string
+		mappings []Mapping
+	}{
+		{"Assistants", r.Assistants},
+		{"Files", r.Files},
+		{"Vector stores", r.VectorStores},
+	}
+
+	for _, section := range sections {
+		if len(section.mappings) < 1 {
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("%v:", section.title))
+
+		for _, mapping := range section.mappings {
+			if mapping.Error != "" {
+				lines = append(lines, fmt.Sprintf("  X %v (%v): %v", mapping.OldID, mapping.Name, mapping.Error))
+			} else {
+				lines = append(lines, fmt.Sprintf("  ✓ %v -> %v (%v)", mapping.OldID, mapping.NewID, mapping.Name))
+			}
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// NumCloned counts the assistants that were recreated.
+func (r Report) NumCloned() int {
+	numCloned := 0
+
+	for _, mapping := range r.Assistants {
+		if mapping.Error == "" {
+			numCloned += 1
+		}
+	}
+
+	return numCloned
+}
@@ -0,0 +1,347 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/vault"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ConfigEnv  = "OAIT_CONFIG"
+	ProfileEnv = "OAIT_PROFILE"
+
+	// DefaultProfile is used when no profile is selected and none is current.
+	DefaultProfile = "default"
+)
+
+type Config struct {
+	Current  string             `yaml:"current,omitempty"`
+	Profiles map[string]Profile `yaml:"profiles,omitempty"`
+}
+
+type Profile struct {
+	Key         string `yaml:"key,omitempty"`
+	KeyCommand  string `yaml:"key_command,omitempty"`
+	KeyFile     string `yaml:"key_file,omitempty"`
+	KeyVault    string `yaml:"key_vault,omitempty"`
+	Org         string `yaml:"org,omitempty"`
+	Project     string `yaml:"project,omitempty"`
+	BaseURL     string `yaml:"base_url,omitempty"`
+	Concurrency int    `yaml:"concurrency,omitempty"`
+	Output      string `yaml:"output,omitempty"`
+}
+
+// Fields are the profile settings `config get|set` accept, in display order.
+var Fields = []string{"key", "key_command", "key_file", "key_vault", "org", "project", "base_url", "concurrency", "output"}
+
+// Load reads the config file (OAIT_CONFIG, or <config dir>/oait/config.yaml). A
+// missing file is an empty config.
+func Load() (*Config, error) {
+	fileName, err := Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := Config{Profiles: make(map[string]Profile)}
+	data, err := os.ReadFile(fileName)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return &cfg, nil
+	}
+
+	if err != nil {
+		err = errors.New("Failed reading config: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	err = yaml.Unmarshal(data, &cfg)
+
+	if err != nil {
+		err = errors.New("Failed parsing config: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]Profile)
+	}
+
+	return &cfg, nil
+}
+
+// Save writes the config file, readable only by the user as it may hold keys.
+func (c *Config) Save() error {
+	fileName, err := Path()
+
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(c)
+
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(fileName), 0700)
+
+	if err == nil {
+		err = os.WriteFile(fileName, data, 0600)
+	}
+
+	if err != nil {
+		err = errors.New("Failed writing config: " + fileName + ". Error: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func Path() (string, error) {
+	fileName := os.Getenv(ConfigEnv)
+
+	if fileName != "" {
+		return fileName, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+
+	if err != nil {
+		err = errors.New("Failed to locate config: " + err.Error())
+		return "", err
+	}
+
+	return filepath.Join(configDir, "oait", "config.yaml"), nil
+}
+
+// Selected names the profile in effect: the --profile flag, then OAIT_PROFILE, then
+// the current profile, then "default". It reports whether it was chosen explicitly.
+func (c *Config) Selected(profileFlag string) (string, bool) {
+	if profileFlag != "" {
+		return profileFlag, true
+	}
+
+	name := os.Getenv(ProfileEnv)
+
+	if name != "" {
+		return name, true
+	}
+
+	if c.Current != "" {
+		return c.Current, true
+	}
+
+	return DefaultProfile, false
+}
+
+// Resolve returns the profile in effect. Naming a profile that doesn't exist is an
+// error; with none named, a missing "default" profile is just empty.
+func (c *Config) Resolve(profileFlag string) (string, *Profile, error) {
+	name, explicit := c.Selected(profileFlag)
+	profile, ok := c.Profiles[name]
+
+	if !ok && explicit {
+		errMsg := fmt.Sprintf("Unknown profile: '%v'. (see `oait config list`)", name)
+		err := errors.New(errMsg)
+		return "", nil, err
+	}
+
+	return name, &profile, nil
+}
+
+// APIKey is the profile's key, the output of its key_command, the contents of its
+// key_file, or its key_vault entry, whichever is set first.
+func (p *Profile) APIKey() (string, error) {
+	if p.Key != "" {
+		return p.Key, nil
+
+	} else if p.KeyCommand != "" {
+		cmd := exec.Command("sh", "-c", p.KeyCommand)
+		cmd.Stderr = os.Stderr
+		out, err := cmd.Output()
+
+		if err != nil {
+			err = errors.New("Failed running key_command. Error: " + err.Error())
+			return "", err
+		}
+
+		return strings.TrimSpace(string(out)), nil
+
+	} else if p.KeyFile != "" {
+		return ReadKeyFile(p.KeyFile)
+
+	} else if p.KeyVault != "" {
+		return vault.Key(p.KeyVault)
+	}
+
+	return "", nil
+}
+
+// ReadKeyFile reads a key from a file, or from stdin for "-". It warns when the file
+// is readable by others.
+func ReadKeyFile(fileName string) (string, error) {
+	var data []byte
+	var err error
+
+	if fileName == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(fileName)
+	}
+
+	if err != nil {
+		err = errors.New("Failed reading key file: " + fileName + ". Error: " + err.Error())
+		return "", err
+	}
+
+	if fileName != "-" && runtime.GOOS != "windows" {
+		stat, err := os.Stat(fileName)
+
+		if err == nil && stat.Mode().Perm()&0077 != 0 {
+			fmt.Printf("WARNING: key file '%v' is readable by others (chmod 600 it)\n", fileName)
+		}
+	}
+
+	key := strings.TrimSpace(string(data))
+
+	if key == "" {
+		err = errors.New("Empty key file: " + fileName)
+		return "", err
+	}
+
+	return key, nil
+}
+
+func (p *Profile) Get(field string) (string, error) {
+	switch field {
+	case "key":
+		return p.Key, nil
+	case "key_command":
+		return p.KeyCommand, nil
+	case "key_file":
+		return p.KeyFile, nil
+	case "key_vault":
+		return p.KeyVault, nil
+	case "org":
+		return p.Org, nil
+	case "project":
+		return p.Project, nil
+	case "base_url":
+		return p.BaseURL, nil
+	case "concurrency":
+		if p.Concurrency == 0 {
+			return "", nil
+		}
+		return strconv.Itoa(p.Concurrency), nil
+	case "output":
+		return p.Output, nil
+	}
+
+	return "", invalidField(field)
+}
+
+// Set sets a field from its string value; an empty value unsets it.
+func (p *Profile) Set(field string, val string) error {
+	switch field {
+	case "key":
+		p.Key = val
+	case "key_command":
+		p.KeyCommand = val
+	case "key_file":
+		p.KeyFile = val
+	case "key_vault":
+		p.KeyVault = val
+	case "org":
+		p.Org = val
+	case "project":
+		p.Project = val
+	case "base_url":
+		p.BaseURL = strings.TrimSuffix(val, "/")
+	case "concurrency":
+		if val == "" {
+			p.Concurrency = 0
+			return nil
+		}
+
+		n, err := strconv.Atoi(val)
+
+		if err != nil || n < 1 {
+			errMsg := fmt.Sprintf("invalid concurrency: '%s'. (should be a positive integer)", val)
+			err = errors.New(errMsg)
+			return err
+		}
+
+		p.Concurrency = n
+	case "output":
+		if val != "" && val != "json" && val != "pretty" {
+			errMsg := fmt.Sprintf("invalid output: '%s'. (should be 'json' | 'pretty')", val)
+			err := errors.New(errMsg)
+			return err
+		}
+
+		p.Output = val
+	default:
+		return invalidField(field)
+	}
+
+	return nil
+}
+
+// Format lists the profile's set fields, with the key masked.
+func (p *Profile) Format() string {
+	lines := []string{}
+
+	for _, field := range Fields {
+		val, _ := p.Get(field)
+
+		if val == "" {
+			continue
+		}
+
+		if field == "key" {
+			val = MaskKey(val)
+		}
+
+		lines = append(lines, fmt.Sprintf("%v: %v", field, val))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Names lists the profile names, sorted.
+func (c *Config) Names() []string {
+	names := make([]string, 0, len(c.Profiles))
+
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// MaskKey keeps only enough of a key to recognise it.
+func MaskKey(key string) string {
+	if len(key) <= 12 {
+		return "****"
+	}
+
+	return key[:7] + "..." + key[len(key)-4:]
+}
+
+func invalidField(field string) error {
+	errMsg := fmt.Sprintf("invalid field: '%s'. (should be one of %v)", field, strings.Join(Fields, " | "))
+	return errors.New(errMsg)
+}
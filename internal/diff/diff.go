@@ -0,0 +1,196 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/openai"
+)
+
+const contextLines = 2
+
+// Assistants lists the fields that differ between a and b, one line each,
+// followed by a line diff of the instructions if they differ.
+func Assistants(a *openai.AsstObject, b *openai.AsstObject) []string {
+	changes := []string{}
+
+	changes = appendField(changes, "name", a.Name, b.Name)
+	changes = appendField(changes, "description", a.Description, b.Description)
+	changes = appendField(changes, "model", a.Model, b.Model)
+	changes = appendField(changes, "temperature", a.Temp, b.Temp)
+	changes = appendField(changes, "top_p", a.TopP, b.TopP)
+	changes = appendField(changes, "response_format", a.ResFormat.String(), b.ResFormat.String())
+	changes = appendField(changes, "tools", ToolNames(a.Tools), ToolNames(b.Tools))
+	changes = appendField(changes, "tool_resources", toJSON(a.ToolResources), toJSON(b.ToolResources))
+
+	for _, key := range metadataKeys(a.Metadata, b.Metadata) {
+		aVal, aOk := a.Metadata[key]
+		bVal, bOk := b.Metadata[key]
+
+		if !aOk {
+			changes = append(changes, fmt.Sprintf("metadata.%v: + %q", key, bVal))
+		} else if !bOk {
+			changes = append(changes, fmt.Sprintf("metadata.%v: - %q", key, aVal))
+		} else if aVal != bVal {
+			changes = append(changes, fmt.Sprintf("metadata.%v: %q -> %q", key, aVal, bVal))
+		}
+	}
+
+	if a.Instructions != b.Instructions {
+		changes = append(changes, "instructions:")
+
+		for _, line := range Lines(a.Instructions, b.Instructions) {
+			changes = append(changes, "  "+line)
+		}
+	}
+
+	for i, tool := range a.Tools {
+		if i < len(b.Tools) && tool.Type == "function" && b.Tools[i].Type == "function" {
+			aFunction := toJSON(tool.Function)
+			bFunction := toJSON(b.Tools[i].Function)
+
+			if aFunction != bFunction {
+				changes = appendField(changes, fmt.Sprintf("tools[%v].function", i), aFunction, bFunction)
+			}
+		}
+	}
+
+	return changes
+}
+
+// Lines is a line diff of a and b: removed lines are prefixed "- ", added "+ ",
+// and unchanged lines "  ", with long unchanged runs collapsed to "...".
+func Lines(a string, b string) []string {
+	aLines := strings.Split(a, "\n")
+	bLines := strings.Split(b, "\n")
+
+	// lcs[i][j] = length of the longest common subsequence of aLines[i:] and bLines[j:]
+	lcs := make([][]int, len(aLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bLines)+1)
+	}
+
+	for i := len(aLines) - 1; i >= 0; i-- {
+		for j := len(bLines) - 1; j >= 0; j-- {
+			if aLines[i] == bLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := []string{}
+	i, j := 0, 0
+
+	for i < len(aLines) || j < len(bLines) {
+		if i < len(aLines) && j < len(bLines) && aLines[i] == bLines[j] {
+			lines = append(lines, "  "+aLines[i])
+			i++
+			j++
+		} else if i < len(aLines) && (j == len(bLines) || lcs[i+1][j] >= lcs[i][j+1]) {
+			lines = append(lines, "- "+aLines[i])
+			i++
+		} else {
+			lines = append(lines, "+ "+bLines[j])
+			j++
+		}
+	}
+
+	return collapse(lines)
+}
+
+func ToolNames(tools []openai.Tool) string {
+	names := make([]string, len(tools))
+
+	for i, tool := range tools {
+		if tool.Type == "function" && tool.Function != nil {
+			names[i] = "function:" + tool.Function.Name
+		} else {
+			names[i] = tool.Type
+		}
+	}
+
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+func appendField[T comparable](changes []string, field string, a T, b T) []string {
+	if a == b {
+		return changes
+	}
+
+	return append(changes, fmt.Sprintf("%v: %v -> %v", field, format(a), format(b)))
+}
+
+func format(val any) string {
+	str, ok := val.(string)
+
+	if ok && !strings.HasPrefix(str, "[") && !strings.HasPrefix(str, "{") {
+		return fmt.Sprintf("%q", str)
+	}
+
+	return fmt.Sprintf("%v", val)
+}
+
+func toJSON(val any) string {
+	b, err := json.Marshal(val)
+
+	if err != nil || string(b) == "null" {
+		return "{}"
+	}
+
+	return string(b)
+}
+
+func metadataKeys(a map[string]string, b map[string]string) []string {
+	keys := []string{}
+
+	for key := range a {
+		keys = append(keys, key)
+	}
+
+	for key := range b {
+		_, ok := a[key]
+
+		if !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func collapse(lines []string) []string {
+	changed := make([]bool, len(lines))
+	for i, line := range lines {
+		changed[i] = !strings.HasPrefix(line, "  ")
+	}
+
+	collapsed := []string{}
+	skipped := false
+
+	for i, line := range lines {
+		near := false
+
+		for k := max(0, i-contextLines); k <= min(len(lines)-1, i+contextLines); k++ {
+			if changed[k] {
+				near = true
+				break
+			}
+		}
+
+		if near {
+			collapsed = append(collapsed, line)
+			skipped = false
+		} else if !skipped {
+			collapsed = append(collapsed, "  ...")
+			skipped = true
+		}
+	}
+
+	return collapsed
+}
@@ -0,0 +1,76 @@
+package embed
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/tokens"
+)
+
+const MaxInputTokens = 8191
+
+// Batches splits records into request batches of at most maxItems records and
+// (estimated) maxTokens tokens, in order. A record too long to embed is an error.
+func Batches(records []Record, maxItems int, maxTokens int) ([][]Record, error) {
+	batches := [][]Record{}
+	batch := []Record{}
+	batchTokens := 0
+
+	for _, record := range records {
+		recordTokens := tokens.Estimate(record.Text)
+
+		if recordTokens > MaxInputTokens {
+			errMsg := fmt.Sprintf("Input '%v' is ~%v tokens, over the %v token limit", record.ID, recordTokens, MaxInputTokens)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+
+		if record.Text == "" {
+			errMsg := fmt.Sprintf("Input '%v' is empty", record.ID)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+
+		if len(batch) > 0 && (len(batch) >= maxItems || batchTokens+recordTokens > maxTokens) {
+			batches = append(batches, batch)
+			batch = []Record{}
+			batchTokens = 0
+		}
+
+		batch = append(batch, record)
+		batchTokens += recordTokens
+	}
+
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches, nil
+}
+
+// Remaining drops records whose IDs are done, erroring on duplicate IDs since
+// they couldn't be told apart on resume.
+func Remaining(records []Record, done []string) ([]Record, error) {
+	doneIDs := make(map[string]bool, len(done))
+	for _, id := range done {
+		doneIDs[id] = true
+	}
+
+	seen := make(map[string]bool, len(records))
+	remaining := []Record{}
+
+	for _, record := range records {
+		if seen[record.ID] {
+			errMsg := fmt.Sprintf("Duplicate input id '%v'", record.ID)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+		seen[record.ID] = true
+
+		if !doneIDs[record.ID] {
+			remaining = append(remaining, record)
+		}
+	}
+
+	return remaining, nil
+}
@@ -0,0 +1,129 @@
+package embed
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type Record struct {
+	ID   string
+	Text string
+}
+
+// LinesInput reads one record per non-empty line, with its line number as the ID.
+func LinesInput(reader io.Reader, name string) ([]Record, error) {
+	records := []Record{}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum += 1
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" {
+			continue
+		}
+
+		records = append(records, Record{ID: strconv.Itoa(lineNum), Text: line})
+	}
+
+	err := scanner.Err()
+
+	if err != nil {
+		err = errors.New("Failed reading input: " + name + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// JSONLInput reads one record per line of JSON, taking the ID and text from the given fields.
+func JSONLInput(fileName string, idField string, textField string) ([]Record, error) {
+	file, err := os.Open(fileName)
+
+	if err != nil {
+		err = errors.New("Failed reading input: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+	defer file.Close()
+
+	records := []Record{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum += 1
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" {
+			continue
+		}
+
+		var obj map[string]any
+		err := json.Unmarshal([]byte(line), &obj)
+
+		if err != nil {
+			errMsg := fmt.Sprintf("Invalid JSON on line %v of '%v': %v", lineNum, fileName, err)
+			err = errors.New(errMsg)
+			return nil, err
+		}
+
+		id, idOk := obj[idField]
+		text, textOk := obj[textField].(string)
+
+		if !idOk || !textOk {
+			errMsg := fmt.Sprintf("Line %v of '%v' needs '%v' and string '%v' fields", lineNum, fileName, idField, textField)
+			err = errors.New(errMsg)
+			return nil, err
+		}
+
+		records = append(records, Record{ID: fmt.Sprintf("%v", id), Text: text})
+	}
+
+	err = scanner.Err()
+
+	if err != nil {
+		err = errors.New("Failed reading input: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// FilesInput makes a record of each file (or each file in each directory), with its path as the ID.
+func FilesInput(paths []string) ([]Record, error) {
+	records := []Record{}
+
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(fileName string, entry os.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+
+			data, err := os.ReadFile(fileName)
+
+			if err != nil {
+				return err
+			}
+
+			records = append(records, Record{ID: fileName, Text: string(data)})
+			return nil
+		})
+
+		if err != nil {
+			err = errors.New("Failed reading input: " + path + ". Error: " + err.Error())
+			return nil, err
+		}
+	}
+
+	return records, nil
+}
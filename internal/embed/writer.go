@@ -0,0 +1,458 @@
+package embed
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Writer appends embeddings to an output file, one batch at a time, so an
+// interrupted run can resume from the IDs already written.
+type Writer interface {
+	Done() []string
+	Write(ids []string, embeddings [][]float32) error
+	Close() error
+}
+
+// FormatFromName picks the output format from the file extension, defaulting to ndjson.
+func FormatFromName(fileName string) string {
+	if strings.HasSuffix(fileName, ".npy") {
+		return "npy"
+	}
+
+	if strings.HasSuffix(fileName, ".bin") || strings.HasSuffix(fileName, ".f32") {
+		return "bin"
+	}
+
+	return "ndjson"
+}
+
+// Open opens fileName for format <ndjson | bin | npy>. An existing file is an error
+// unless resuming, in which case any partially written tail is truncated away.
+func Open(fileName string, format string, resume bool) (Writer, error) {
+	_, err := os.Stat(fileName)
+	exists := err == nil
+
+	if exists && !resume {
+		errMsg := fmt.Sprintf("Output '%v' exists: use --resume to continue it, or remove it", fileName)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	switch format {
+	case "ndjson":
+		return openNDJSON(fileName)
+	case "bin":
+		return openBinary(fileName)
+	case "npy":
+		return openNPY(fileName)
+	}
+
+	errMsg := fmt.Sprintf("invalid format: '%s'. (should be 'ndjson' | 'bin' | 'npy')", format)
+	err = errors.New(errMsg)
+	return nil, err
+}
+
+// ndjson: one {"id": ..., "embedding": [...]} per line.
+
+type ndjsonWriter struct {
+	file *os.File
+	done []string
+}
+
+type ndjsonRecord struct {
+	ID        string    `json:"id"`
+	Embedding []float32 `json:"embedding"`
+}
+
+func openNDJSON(fileName string) (Writer, error) {
+	file, err := os.OpenFile(fileName, os.O_RDWR|os.O_CREATE, 0644)
+
+	if err != nil {
+		err = errors.New("Failed opening output: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	w := ndjsonWriter{file: file, done: []string{}}
+	reader := bufio.NewReader(file)
+	var offset int64
+
+	for {
+		line, err := reader.ReadBytes('\n')
+
+		if err != nil { // EOF, with any partial line discarded
+			break
+		}
+
+		var record ndjsonRecord
+
+		if json.Unmarshal(line, &record) != nil {
+			break
+		}
+
+		w.done = append(w.done, record.ID)
+		offset += int64(len(line))
+	}
+
+	err = truncateAt(file, offset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+func (w *ndjsonWriter) Done() []string {
+	return w.done
+}
+
+func (w *ndjsonWriter) Write(ids []string, embeddings [][]float32) error {
+	var buf bytes.Buffer
+
+	for i, id := range ids {
+		line, err := json.Marshal(ndjsonRecord{ID: id, Embedding: embeddings[i]})
+
+		if err != nil {
+			return err
+		}
+
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	err := writeSync(w.file, buf.Bytes())
+
+	if err != nil {
+		return err
+	}
+
+	w.done = append(w.done, ids...)
+
+	return nil
+}
+
+func (w *ndjsonWriter) Close() error {
+	return w.file.Close()
+}
+
+// bin: "OAIE", uint32 dimensions, then per record uint32 id length, id, and
+// dimensions float32s, all little-endian.
+
+const binaryMagic = "OAIE"
+
+type binaryWriter struct {
+	file *os.File
+	dims int
+	done []string
+}
+
+func openBinary(fileName string) (Writer, error) {
+	file, err := os.OpenFile(fileName, os.O_RDWR|os.O_CREATE, 0644)
+
+	if err != nil {
+		err = errors.New("Failed opening output: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	w := binaryWriter{file: file, done: []string{}}
+	reader := bufio.NewReader(file)
+
+	header := make([]byte, 8)
+	_, err = io.ReadFull(reader, header)
+
+	if err != nil { // New (or headerless) file
+		return &w, truncateAt(file, 0)
+	}
+
+	if string(header[:4]) != binaryMagic {
+		file.Close()
+		err = errors.New("Failed resuming output: " + fileName + ". Not an embeddings .bin file.")
+		return nil, err
+	}
+
+	w.dims = int(binary.LittleEndian.Uint32(header[4:]))
+	offset := int64(len(header))
+
+	for {
+		idLen := make([]byte, 4)
+		_, err := io.ReadFull(reader, idLen)
+
+		if err != nil {
+			break
+		}
+
+		record := make([]byte, int(binary.LittleEndian.Uint32(idLen))+4*w.dims)
+		_, err = io.ReadFull(reader, record)
+
+		if err != nil {
+			break
+		}
+
+		w.done = append(w.done, string(record[:len(record)-4*w.dims]))
+		offset += int64(len(idLen) + len(record))
+	}
+
+	err = truncateAt(file, offset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+func (w *binaryWriter) Done() []string {
+	return w.done
+}
+
+func (w *binaryWriter) Write(ids []string, embeddings [][]float32) error {
+	var buf bytes.Buffer
+
+	if w.dims == 0 && len(embeddings) > 0 {
+		w.dims = len(embeddings[0])
+		buf.WriteString(binaryMagic)
+		binary.Write(&buf, binary.LittleEndian, uint32(w.dims))
+	}
+
+	for i, id := range ids {
+		if len(embeddings[i]) != w.dims {
+			errMsg := fmt.Sprintf("Embedding for '%v' has %v dimensions, file has %v", id, len(embeddings[i]), w.dims)
+			err := errors.New(errMsg)
+			return err
+		}
+
+		binary.Write(&buf, binary.LittleEndian, uint32(len(id)))
+		buf.WriteString(id)
+		binary.Write(&buf, binary.LittleEndian, embeddings[i])
+	}
+
+	err := writeSync(w.file, buf.Bytes())
+
+	if err != nil {
+		return err
+	}
+
+	w.done = append(w.done, ids...)
+
+	return nil
+}
+
+func (w *binaryWriter) Close() error {
+	return w.file.Close()
+}
+
+// npy: a NumPy v1.0 float32 (N, dimensions) array, with the IDs one per line in
+// <file>.ids. The header is fixed-size so it can be rewritten as rows are added.
+
+const npyHeaderLen = 128
+
+var npyShape = regexp.MustCompile(`'shape': \((\d+), (\d+)\)`)
+
+type npyWriter struct {
+	file    *os.File
+	idsFile *os.File
+	dims    int
+	done    []string
+}
+
+func openNPY(fileName string) (Writer, error) {
+	file, err := os.OpenFile(fileName, os.O_RDWR|os.O_CREATE, 0644)
+
+	if err != nil {
+		err = errors.New("Failed opening output: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	idsFile, err := os.OpenFile(fileName+".ids", os.O_RDWR|os.O_CREATE, 0644)
+
+	if err != nil {
+		file.Close()
+		err = errors.New("Failed opening output: " + fileName + ".ids. Error: " + err.Error())
+		return nil, err
+	}
+
+	w := npyWriter{file: file, idsFile: idsFile, done: []string{}}
+
+	header := make([]byte, npyHeaderLen)
+	_, err = io.ReadFull(file, header)
+
+	if err != nil { // New (or headerless) file
+		err = truncateAt(file, 0)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &w, truncateAt(idsFile, 0)
+	}
+
+	match := npyShape.FindSubmatch(header)
+
+	if !bytes.HasPrefix(header, []byte("\x93NUMPY")) || match == nil {
+		w.Close()
+		err = errors.New("Failed resuming output: " + fileName + ". Not an embeddings .npy file.")
+		return nil, err
+	}
+
+	fmt.Sscan(string(match[2]), &w.dims)
+
+	info, err := file.Stat()
+
+	if err != nil {
+		return nil, err
+	}
+
+	numRows := int((info.Size() - npyHeaderLen) / int64(4*max(w.dims, 1)))
+
+	// Keep only rows that have both data and an id
+	reader := bufio.NewReader(idsFile)
+	var idsOffset int64
+
+	for len(w.done) < numRows {
+		line, err := reader.ReadString('\n')
+
+		if err != nil {
+			break
+		}
+
+		w.done = append(w.done, strings.TrimSuffix(line, "\n"))
+		idsOffset += int64(len(line))
+	}
+
+	err = truncateAt(idsFile, idsOffset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = truncateAt(file, npyHeaderLen+int64(len(w.done)*4*w.dims))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, w.writeHeader()
+}
+
+func (w *npyWriter) Done() []string {
+	return w.done
+}
+
+func (w *npyWriter) Write(ids []string, embeddings [][]float32) error {
+	if w.dims == 0 && len(embeddings) > 0 {
+		w.dims = len(embeddings[0])
+
+		err := w.writeHeader()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	var rows bytes.Buffer
+	var idLines bytes.Buffer
+
+	for i, id := range ids {
+		if len(embeddings[i]) != w.dims {
+			errMsg := fmt.Sprintf("Embedding for '%v' has %v dimensions, file has %v", id, len(embeddings[i]), w.dims)
+			err := errors.New(errMsg)
+			return err
+		}
+
+		if strings.Contains(id, "\n") {
+			errMsg := fmt.Sprintf("Id %q can't contain a newline in .npy output", id)
+			err := errors.New(errMsg)
+			return err
+		}
+
+		binary.Write(&rows, binary.LittleEndian, embeddings[i])
+		idLines.WriteString(id + "\n")
+	}
+
+	err := writeSync(w.file, rows.Bytes())
+
+	if err != nil {
+		return err
+	}
+
+	err = writeSync(w.idsFile, idLines.Bytes())
+
+	if err != nil {
+		return err
+	}
+
+	w.done = append(w.done, ids...)
+
+	return w.writeHeader()
+}
+
+func (w *npyWriter) writeHeader() error {
+	dict := fmt.Sprintf("{'descr': '<f4', 'fortran_order': False, 'shape': (%d, %d), }", len(w.done), w.dims)
+
+	header := make([]byte, 0, npyHeaderLen)
+	header = append(header, "\x93NUMPY\x01\x00"...)
+	header = binary.LittleEndian.AppendUint16(header, npyHeaderLen-10)
+	header = append(header, dict...)
+
+	for len(header) < npyHeaderLen-1 {
+		header = append(header, ' ')
+	}
+	header = append(header, '\n')
+
+	_, err := w.file.WriteAt(header, 0)
+
+	if err != nil {
+		err = errors.New("Failed writing output header. Error: " + err.Error())
+		return err
+	}
+
+	return w.file.Sync()
+}
+
+func (w *npyWriter) Close() error {
+	w.idsFile.Close()
+	return w.file.Close()
+}
+
+func truncateAt(file *os.File, offset int64) error {
+	err := file.Truncate(offset)
+
+	if err == nil {
+		_, err = file.Seek(offset, io.SeekStart)
+	}
+
+	if err != nil {
+		file.Close()
+		err = errors.New("Failed preparing output: " + file.Name() + ". Error: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func writeSync(file *os.File, data []byte) error {
+	_, err := file.Seek(0, io.SeekEnd)
+
+	if err == nil {
+		_, err = file.Write(data)
+	}
+
+	if err == nil {
+		err = file.Sync()
+	}
+
+	if err != nil {
+		err = errors.New("Failed writing output: " + file.Name() + ". Error: " + err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,338 @@
+package finetune
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/tokens"
+)
+
+const (
+	// MinExamples is the fewest examples a fine-tuning job accepts.
+	MinExamples = 10
+	// MaxExampleTokens is the longest example used in full; longer ones are truncated.
+	MaxExampleTokens = 65536
+
+	// Per-message and per-example overheads of the chat format, in tokens.
+	messageOverhead = 4
+	exampleOverhead = 2
+
+	// Bounds the default (auto) epoch count aims to keep total examples seen within.
+	minTargetExamples = 100
+	maxTargetExamples = 25000
+	defaultEpochs     = 3
+	minDefaultEpochs  = 1
+	maxDefaultEpochs  = 25
+)
+
+var validRoles = map[string]bool{
+	"system":    true,
+	"user":      true,
+	"assistant": true,
+	"tool":      true,
+	"function":  true,
+}
+
+// Prices are USD per 1M training tokens, matched by longest model prefix.
+var Prices = map[string]float64{
+	"gpt-4.1-nano":  1.50,
+	"gpt-4.1-mini":  5.00,
+	"gpt-4.1":       25.00,
+	"gpt-4o-mini":   3.00,
+	"gpt-4o":        25.00,
+	"gpt-3.5-turbo": 8.00,
+}
+
+type Report struct {
+	FileName    string
+	Examples    int
+	Errors      []string
+	Warnings    []string
+	TotalTokens int
+	TrainTokens int // Total with each example capped at MaxExampleTokens
+	MinTokens   int
+	MaxTokens   int
+	OverLimit   int
+}
+
+type example struct {
+	Messages []message `json:"messages"`
+	Tools    []any     `json:"tools,omitempty"`
+}
+
+type message struct {
+	Role         string          `json:"role"`
+	Content      json.RawMessage `json:"content"`
+	Name         string          `json:"name,omitempty"`
+	ToolCalls    json.RawMessage `json:"tool_calls,omitempty"`
+	FunctionCall json.RawMessage `json:"function_call,omitempty"`
+	ToolCallID   string          `json:"tool_call_id,omitempty"`
+	Weight       *int            `json:"weight,omitempty"`
+}
+
+// Validate checks a chat-format training file line by line: each line must be an
+// object with a non-empty messages list of known roles, string or content-part array
+// content (or tool calls), and at least one assistant message. It also estimates each example's tokens.
+func Validate(fileName string) (*Report, error) {
+	file, err := os.Open(fileName)
+
+	if err != nil {
+		err = errors.New("Failed reading training file: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+	defer file.Close()
+
+	report := Report{FileName: fileName}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum += 1
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" {
+			continue
+		}
+
+		numTokens, problems := checkExample(line)
+
+		for _, problem := range problems {
+			report.Errors = append(report.Errors, fmt.Sprintf("line %v: %v", lineNum, problem))
+		}
+
+		if len(problems) > 0 {
+			continue
+		}
+
+		report.Examples += 1
+		report.TotalTokens += numTokens
+		report.TrainTokens += min(numTokens, MaxExampleTokens)
+
+		if report.MinTokens == 0 || numTokens < report.MinTokens {
+			report.MinTokens = numTokens
+		}
+
+		if numTokens > report.MaxTokens {
+			report.MaxTokens = numTokens
+		}
+
+		if numTokens > MaxExampleTokens {
+			report.OverLimit += 1
+			report.Warnings = append(report.Warnings, fmt.Sprintf("line %v: ~%v tokens, will be truncated to %v", lineNum, numTokens, MaxExampleTokens))
+		}
+	}
+
+	err = scanner.Err()
+
+	if err != nil {
+		err = errors.New("Failed reading training file: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	if report.Examples < MinExamples {
+		report.Errors = append(report.Errors, fmt.Sprintf("%v valid examples, at least %v are required", report.Examples, MinExamples))
+	}
+
+	return &report, nil
+}
+
+func checkExample(line string) (int, []string) {
+	var ex example
+	err := json.Unmarshal([]byte(line), &ex)
+
+	if err != nil {
+		return 0, []string{"invalid JSON: " + err.Error()}
+	}
+
+	if len(ex.Messages) < 1 {
+		return 0, []string{"missing messages"}
+	}
+
+	problems := []string{}
+	numTokens := exampleOverhead
+	hasAssistant := false
+
+	for i, msg := range ex.Messages {
+		if !validRoles[msg.Role] {
+			problems = append(problems, fmt.Sprintf("message %v: invalid role '%v'", i+1, msg.Role))
+			continue
+		}
+
+		if msg.Role == "assistant" {
+			hasAssistant = true
+		} else if msg.Weight != nil {
+			problems = append(problems, fmt.Sprintf("message %v: weight is only allowed on assistant messages", i+1))
+		}
+
+		if msg.Weight != nil && *msg.Weight != 0 && *msg.Weight != 1 {
+			problems = append(problems, fmt.Sprintf("message %v: weight must be 0 or 1", i+1))
+		}
+
+		var content string
+		hasCall := len(msg.ToolCalls) > 0 || len(msg.FunctionCall) > 0
+
+		if len(msg.Content) > 0 && string(msg.Content) != "null" {
+			content, err = contentText(msg.Content)
+
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("message %v: %v", i+1, err))
+			}
+		} else if !(msg.Role == "assistant" && hasCall) {
+			problems = append(problems, fmt.Sprintf("message %v: missing content", i+1))
+		}
+
+		numTokens += messageOverhead + tokens.Estimate(content) + tokens.Estimate(msg.Name)
+		numTokens += tokens.Estimate(string(msg.ToolCalls)) + tokens.Estimate(string(msg.FunctionCall))
+	}
+
+	if !hasAssistant {
+		problems = append(problems, "no assistant message to train on")
+	}
+
+	if len(ex.Tools) > 0 {
+		tools, _ := json.Marshal(ex.Tools)
+		numTokens += tokens.Estimate(string(tools))
+	}
+
+	return numTokens, problems
+}
+
+// contentText is a message's text: its content if a string, or the text of its
+// parts if an array of content parts (e.g. text and image_url, for vision).
+func contentText(raw json.RawMessage) (string, error) {
+	var content string
+
+	if json.Unmarshal(raw, &content) == nil {
+		return content, nil
+	}
+
+	var parts []map[string]any
+
+	if json.Unmarshal(raw, &parts) != nil {
+		err := errors.New("content must be a string or an array of content parts")
+		return "", err
+	}
+
+	texts := []string{}
+
+	for j, part := range parts {
+		partType, _ := part["type"].(string)
+
+		if partType == "" {
+			errMsg := fmt.Sprintf("content part %v: missing type", j+1)
+			err := errors.New(errMsg)
+			return "", err
+		}
+
+		if partType != "text" {
+			continue
+		}
+
+		text, ok := part["text"].(string)
+
+		if !ok {
+			errMsg := fmt.Sprintf("content part %v: text must be a string", j+1)
+			err := errors.New(errMsg)
+			return "", err
+		}
+
+		texts = append(texts, text)
+	}
+
+	return strings.Join(texts, "\n"), nil
+}
+
+// Epochs is the epoch count a job will use: the given one, or what "auto" picks for
+// this many examples.
+func Epochs(numExamples int, epochs int) int {
+	if epochs > 0 {
+		return epochs
+	}
+
+	if numExamples < 1 {
+		return defaultEpochs
+	}
+
+	if numExamples*defaultEpochs < minTargetExamples {
+		return min(maxDefaultEpochs, int(math.Ceil(float64(minTargetExamples)/float64(numExamples))))
+	}
+
+	if numExamples*defaultEpochs > maxTargetExamples {
+		return max(minDefaultEpochs, maxTargetExamples/numExamples)
+	}
+
+	return defaultEpochs
+}
+
+// BilledTokens is the training tokens charged: the capped tokens times the epochs.
+func (r *Report) BilledTokens(epochs int) int {
+	return r.TrainTokens * Epochs(r.Examples, epochs)
+}
+
+// Price looks up the training price for a model, by longest matching prefix.
+func Price(model string) (float64, bool) {
+	model = strings.TrimPrefix(model, "ft:")
+	prefixes := make([]string, 0, len(Prices))
+
+	for prefix := range Prices {
+		prefixes = append(prefixes, prefix)
+	}
+
+	sort.Slice(prefixes, func(i, j int) bool {
+		return len(prefixes[i]) > len(prefixes[j])
+	})
+
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(model, prefix) {
+			return Prices[prefix], true
+		}
+	}
+
+	return 0, false
+}
+
+// Format summarises the report, with a cost estimate when price > 0.
+func (r *Report) Format(epochs int, price float64) string {
+	lines := []string{}
+
+	for _, problem := range r.Errors {
+		lines = append(lines, "ERROR: "+problem)
+	}
+
+	for _, warning := range r.Warnings {
+		lines = append(lines, "WARNING: "+warning)
+	}
+
+	if len(lines) > 0 {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, fmt.Sprintf("File:      %v", r.FileName))
+	lines = append(lines, fmt.Sprintf("Examples:  %v valid, %v errors", r.Examples, len(r.Errors)))
+
+	if r.Examples > 0 {
+		lines = append(lines, fmt.Sprintf("Tokens:    ~%v total, ~%v-%v per example (~%v mean)", r.TotalTokens, r.MinTokens, r.MaxTokens, r.TotalTokens/r.Examples))
+
+		numEpochs := Epochs(r.Examples, epochs)
+		billed := r.BilledTokens(epochs)
+		lines = append(lines, fmt.Sprintf("Training:  %v epochs, ~%v billed tokens", numEpochs, billed))
+
+		if price > 0 {
+			lines = append(lines, fmt.Sprintf("Cost:      ~$%.2f (at $%.2f / 1M tokens)", float64(billed)*price/1e6, price))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Valid reports whether the file can be used for training.
+func (r *Report) Valid() bool {
+	return len(r.Errors) < 1
+}
@@ -1,12 +1,16 @@
 package io
 
 import (
+	"bufio"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/jackitaliano/oait/internal/openai"
 )
 
@@ -78,6 +82,158 @@ func JSONInput[T any](fileName string) (*T, error) {
 	return &jsonData, nil
 }
 
+// YAMLInput decodes YAML through JSON, so T's json tags apply.
+func YAMLInput[T any](fileName string) (*T, error) {
+	if !strings.HasSuffix(fileName, ".yaml") && !strings.HasSuffix(fileName, ".yml") {
+		errMsg := fmt.Sprintf("Invalid file name: '%v' Only YAML is valid.", fileName)
+		err := errors.New(errMsg)
+		return nil, err
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		err = errors.New("Failed reading file: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	var yamlData any
+	err = yaml.Unmarshal(data, &yamlData)
+
+	if err != nil {
+		err = errors.New("Failed parsing file: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(yamlData)
+
+	if err != nil {
+		err = errors.New("Failed parsing file: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	var decoded T
+	err = json.Unmarshal(jsonData, &decoded)
+
+	if err != nil {
+		err = errors.New("Failed parsing file: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	return &decoded, nil
+}
+
+func MessagesInput(fileName string, defaultRole string) ([]openai.CreatedMessage, error) {
+	var inputs []MessageInput
+	var err error
+
+	if strings.HasSuffix(fileName, ".jsonl") {
+		inputs, err = jsonlMessagesInput(fileName)
+
+	} else if strings.HasSuffix(fileName, ".json") {
+		inputs, err = jsonMessagesInput(fileName)
+
+	} else {
+		errMsg := fmt.Sprintf("Invalid file name: '%v' Only JSON and JSONL are valid.", fileName)
+		err = errors.New(errMsg)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]openai.CreatedMessage, len(inputs))
+
+	for i, input := range inputs {
+		if input.Text == "" {
+			errMsg := fmt.Sprintf("Message %v in '%v' has no text", i+1, fileName)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+
+		role := input.Role
+		if role == "" {
+			role = defaultRole
+		}
+
+		messages[i] = *CreateMessage(input.Text, role, input.Attachments)
+	}
+
+	return messages, nil
+}
+
+func TextInput(fileName string) (string, error) {
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		err = errors.New("Failed reading file: " + fileName + ". Error: " + err.Error())
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func BytesInput(fileName string) ([]byte, error) {
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		err = errors.New("Failed reading file: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func StdinInput() (string, error) {
+	data, err := io.ReadAll(os.Stdin)
+
+	if err != nil {
+		err = errors.New("Failed reading stdin. Error: " + err.Error())
+		return "", err
+	}
+
+	text := strings.TrimRight(string(data), "\r\n")
+
+	if text == "" {
+		err = errors.New("No input read from stdin")
+		return "", err
+	}
+
+	return text, nil
+}
+
+func AttachmentsInput(attachments []string) ([]openai.Attachment, error) {
+	parsed := make([]openai.Attachment, len(attachments))
+
+	for i, attachmentStr := range attachments {
+		attachmentSplit := strings.SplitN(attachmentStr, ":", 2)
+		fileID := strings.Trim(attachmentSplit[0], " ")
+
+		if fileID == "" || len(attachmentSplit) < 2 {
+			errMsg := fmt.Sprintf("invalid attachment: '%s'. (should be '<file_id>:<tool>[,<tool>]')", attachmentStr)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+
+		tools := []openai.Tool{}
+		for _, toolType := range strings.Split(attachmentSplit[1], ",") {
+			toolType = strings.Trim(toolType, " ")
+
+			if toolType != "code_interpreter" && toolType != "file_search" {
+				errMsg := fmt.Sprintf("invalid attachment tool: '%s'. (should be 'code_interpreter' | 'file_search')", toolType)
+				err := errors.New(errMsg)
+				return nil, err
+			}
+
+			tools = append(tools, openai.Tool{Type: toolType})
+		}
+
+		parsed[i] = openai.Attachment{FileId: fileID, Tools: tools}
+	}
+
+	return parsed, nil
+}
+
 func SessionInput(sessionID string, orgID string) ([]string, error) {
 	sessionThreadsRes, err := openai.GetSessionThreads(sessionID, orgID)
 
@@ -117,6 +273,74 @@ func txtInput(fileName string) ([]string, error) {
 	return asstIDs, nil
 }
 
+func jsonMessagesInput(fileName string) ([]MessageInput, error) {
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		err = errors.New("Failed reading file: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	var inputs []MessageInput
+	err = json.Unmarshal(data, &inputs)
+
+	if err != nil { // Fall back to a single message object
+		var input MessageInput
+		singleErr := json.Unmarshal(data, &input)
+
+		if singleErr != nil {
+			err = errors.New("Failed parsing file: " + fileName + ". Error: " + err.Error())
+			return nil, err
+		}
+
+		inputs = []MessageInput{input}
+	}
+
+	return inputs, nil
+}
+
+func jsonlMessagesInput(fileName string) ([]MessageInput, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		err = errors.New("Failed reading file: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+	defer file.Close()
+
+	inputs := []MessageInput{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum += 1
+		line := strings.Trim(scanner.Text(), " \t\r")
+
+		if line == "" {
+			continue
+		}
+
+		var input MessageInput
+		err = json.Unmarshal([]byte(line), &input)
+
+		if err != nil {
+			errMsg := fmt.Sprintf("Failed parsing line %v of '%v'. Error: %v", lineNum, fileName, err)
+			err = errors.New(errMsg)
+			return nil, err
+		}
+
+		inputs = append(inputs, input)
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		err = errors.New("Failed reading file: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	return inputs, nil
+}
+
 func splitIDs(str string, delimeter string) []string {
 	trimmedString := strings.Trim(str, " ")
 	splitString := strings.Split(trimmedString, delimeter)
@@ -2,7 +2,10 @@ package io
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 func FileOutput(fileName string, data *[]byte) error {
@@ -15,3 +18,10 @@ func FileOutput(fileName string, data *[]byte) error {
 
 	return nil
 }
+
+// NumberedFileName numbers fileName before its extension: out.png -> out-1.png.
+func NumberedFileName(fileName string, i int) string {
+	ext := filepath.Ext(fileName)
+
+	return fmt.Sprintf("%v-%v%v", strings.TrimSuffix(fileName, ext), i, ext)
+}
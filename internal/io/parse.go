@@ -1,10 +1,12 @@
 package io
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 
 	"github.com/jackitaliano/oait/internal/openai"
+	"gopkg.in/yaml.v3"
 )
 
 type Message struct {
@@ -17,8 +19,14 @@ type Thread struct {
 	Messages []Message `json:"messages,omitempty"`
 }
 
-func CreateMessage(text string, role string) *openai.CreatedMessage {
-	message := openai.CreatedMessage{Role: role, Content: text}
+type MessageInput struct {
+	Role        string              `json:"role"`
+	Text        string              `json:"text"`
+	Attachments []openai.Attachment `json:"attachments,omitempty"`
+}
+
+func CreateMessage(text string, role string, attachments []openai.Attachment) *openai.CreatedMessage {
+	message := openai.CreatedMessage{Role: role, Content: text, Attachments: attachments}
 
 	return &message
 }
@@ -50,6 +58,21 @@ func ObjToJSON[T any](obj *T) ([]byte, error) {
 	return b, nil
 }
 
+func ObjToYAML[T any](obj *T) ([]byte, error) {
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+
+	err := encoder.Encode(*obj)
+
+	if err != nil {
+		err = errors.New("YAML Marshal failed with error: " + err.Error())
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
 func ListToJSON[T any](list *[]T) ([]byte, error) {
 	b, err := json.MarshalIndent(*list, "", "  ")
 
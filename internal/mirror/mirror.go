@@ -0,0 +1,357 @@
+package mirror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackitaliano/oait/internal/openai"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const MirrorEnv = "OAIT_MIRROR"
+
+var (
+	threadsBucket  = []byte("threads")
+	messagesBucket = []byte("messages") // a nested bucket of messages per thread
+	filesBucket    = []byte("files")
+	asstsBucket    = []byte("assts")
+	stateBucket    = []byte("state")
+)
+
+// Entry is a mirrored object, with when it was last synced and, once it's gone from
+// the API, when that was noticed. Threads also keep the last message synced.
+type Entry[T any] struct {
+	Object    T      `json:"object"`
+	Cursor    string `json:"cursor,omitempty"`
+	SyncedAt  int64  `json:"synced_at"`
+	DeletedAt int64  `json:"deleted_at,omitempty"`
+}
+
+// Mirror is a local copy of threads, messages, files and assistants in a single
+// bbolt file (OAIT_MIRROR, or <config dir>/oait/mirror.db).
+type Mirror struct {
+	db *bolt.DB
+}
+
+// Open opens the mirror, creating it unless readOnly.
+func Open(readOnly bool) (*Mirror, error) {
+	fileName, err := Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if readOnly {
+		_, err = os.Stat(fileName)
+
+		if errors.Is(err, os.ErrNotExist) {
+			err = errors.New("No mirror at: " + fileName + ". Run `oait sync` first")
+			return nil, err
+		}
+	} else {
+		err = os.MkdirAll(filepath.Dir(fileName), 0700)
+
+		if err != nil {
+			err = errors.New("Failed creating mirror: " + fileName + ". Error: " + err.Error())
+			return nil, err
+		}
+	}
+
+	options := bolt.Options{ReadOnly: readOnly, Timeout: 5 * time.Second}
+	db, err := bolt.Open(fileName, 0600, &options)
+
+	if err != nil {
+		err = errors.New("Failed opening mirror: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	if !readOnly {
+		err = db.Update(func(tx *bolt.Tx) error {
+			for _, bucket := range [][]byte{threadsBucket, messagesBucket, filesBucket, asstsBucket, stateBucket} {
+				_, err := tx.CreateBucketIfNotExists(bucket)
+
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+
+	return &Mirror{db}, nil
+}
+
+func (m *Mirror) Close() error {
+	return m.db.Close()
+}
+
+func Path() (string, error) {
+	fileName := os.Getenv(MirrorEnv)
+
+	if fileName != "" {
+		return fileName, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+
+	if err != nil {
+		err = errors.New("Failed to locate mirror: " + err.Error())
+		return "", err
+	}
+
+	return filepath.Join(configDir, "oait", "mirror.db"), nil
+}
+
+// ThreadIDs lists the mirrored threads, newest first.
+func (m *Mirror) ThreadIDs(includeDeleted bool) ([]string, error) {
+	entries, _, err := list[openai.Thread](m, threadsBucket, nil, includeDeleted)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Object.CreatedAt > entries[j].Object.CreatedAt
+	})
+
+	threadIDs := make([]string, len(entries))
+
+	for i, entry := range entries {
+		threadIDs[i] = entry.Object.ID
+	}
+
+	return threadIDs, nil
+}
+
+// Threads are the mirrored threads with the given IDs, and the IDs that aren't mirrored.
+func (m *Mirror) Threads(threadIDs []string) (*[]openai.Thread, []string, error) {
+	entries, missing, err := list[openai.Thread](m, threadsBucket, threadIDs, true)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	threads := make([]openai.Thread, len(entries))
+
+	for i, entry := range entries {
+		threads[i] = entry.Object
+	}
+
+	return &threads, missing, nil
+}
+
+// ThreadsMessages are the mirrored messages of each thread, newest first like the API
+// returns them, and the IDs that aren't mirrored (which get no messages).
+func (m *Mirror) ThreadsMessages(threadIDs []string) (*[]openai.Messages, []string, error) {
+	threadsMessages := []openai.Messages{}
+	missing := []string{}
+
+	err := m.db.View(func(tx *bolt.Tx) error {
+		threads := tx.Bucket(threadsBucket)
+		messages := tx.Bucket(messagesBucket)
+
+		for _, threadID := range threadIDs {
+			thread := openai.Messages{Messages: []openai.Message{}}
+
+			if threads == nil || threads.Get([]byte(threadID)) == nil {
+				missing = append(missing, threadID)
+				threadsMessages = append(threadsMessages, thread)
+				continue
+			}
+
+			if bucket := messages.Bucket([]byte(threadID)); bucket != nil {
+				err := bucket.ForEach(func(_ []byte, data []byte) error {
+					var message openai.Message
+					err := json.Unmarshal(data, &message)
+					thread.Messages = append(thread.Messages, message)
+					return err
+				})
+
+				if err != nil {
+					return err
+				}
+			}
+
+			sort.SliceStable(thread.Messages, func(i, j int) bool {
+				return thread.Messages[i].CreatedAt > thread.Messages[j].CreatedAt
+			})
+
+			threadsMessages = append(threadsMessages, thread)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		err = errors.New("Failed reading mirror. Error: " + err.Error())
+		return nil, nil, err
+	}
+
+	return &threadsMessages, missing, nil
+}
+
+// Files are the mirrored files with the given IDs (all of them, newest first, for
+// nil IDs), and the IDs that aren't mirrored.
+func (m *Mirror) Files(fileIDs []string, includeDeleted bool) (*[]openai.FileObject, []string, error) {
+	entries, missing, err := list[openai.FileObject](m, filesBucket, fileIDs, includeDeleted)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fileObjects := make([]openai.FileObject, len(entries))
+
+	for i, entry := range entries {
+		fileObjects[i] = entry.Object
+	}
+
+	if fileIDs == nil {
+		sort.SliceStable(fileObjects, func(i, j int) bool {
+			return fileObjects[i].GetCreatedAt() > fileObjects[j].GetCreatedAt()
+		})
+	}
+
+	return &fileObjects, missing, nil
+}
+
+// Assts are the mirrored assistants with the given IDs (all of them, newest first,
+// for nil IDs), and the IDs that aren't mirrored.
+func (m *Mirror) Assts(asstIDs []string, includeDeleted bool) (*[]openai.AsstObject, []string, error) {
+	entries, missing, err := list[openai.AsstObject](m, asstsBucket, asstIDs, includeDeleted)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	asstObjects := make([]openai.AsstObject, len(entries))
+
+	for i, entry := range entries {
+		asstObjects[i] = entry.Object
+	}
+
+	if asstIDs == nil {
+		sort.SliceStable(asstObjects, func(i, j int) bool {
+			return asstObjects[i].GetCreatedAt() > asstObjects[j].GetCreatedAt()
+		})
+	}
+
+	return &asstObjects, missing, nil
+}
+
+// list reads the entries with the given IDs from a bucket, or every entry for nil
+// IDs. Deleted entries are only listed when asked for, or asked for by ID.
+func list[T any](m *Mirror, bucket []byte, ids []string, includeDeleted bool) ([]Entry[T], []string, error) {
+	entries := []Entry[T]{}
+	missing := []string{}
+
+	err := m.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+
+		if ids == nil {
+			if b == nil {
+				return nil
+			}
+
+			return b.ForEach(func(_ []byte, data []byte) error {
+				var entry Entry[T]
+				err := json.Unmarshal(data, &entry)
+
+				if err == nil && (includeDeleted || entry.DeletedAt == 0) {
+					entries = append(entries, entry)
+				}
+
+				return err
+			})
+		}
+
+		for _, id := range ids {
+			var data []byte
+
+			if b != nil {
+				data = b.Get([]byte(id))
+			}
+
+			if data == nil {
+				missing = append(missing, id)
+				continue
+			}
+
+			var entry Entry[T]
+			err := json.Unmarshal(data, &entry)
+
+			if err != nil {
+				return err
+			}
+
+			entries = append(entries, entry)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		err = errors.New("Failed reading mirror. Error: " + err.Error())
+		return nil, nil, err
+	}
+
+	return entries, missing, nil
+}
+
+func get[T any](tx *bolt.Tx, bucket []byte, id string) (*Entry[T], error) {
+	data := tx.Bucket(bucket).Get([]byte(id))
+
+	if data == nil {
+		return nil, nil
+	}
+
+	var entry Entry[T]
+	err := json.Unmarshal(data, &entry)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func put[T any](tx *bolt.Tx, bucket []byte, id string, entry *Entry[T]) error {
+	data, err := json.Marshal(entry)
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Bucket(bucket).Put([]byte(id), data)
+}
+
+// highWater is the newest created_at synced of a kind of object.
+func (m *Mirror) highWater(bucket []byte) int64 {
+	var highWater int64
+
+	m.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(stateBucket).Get(bucket)
+		highWater, _ = strconv.ParseInt(string(data), 10, 64)
+		return nil
+	})
+
+	return highWater
+}
+
+func (m *Mirror) setHighWater(bucket []byte, highWater int64) error {
+	return m.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(stateBucket).Put(bucket, []byte(fmt.Sprint(highWater)))
+	})
+}
@@ -0,0 +1,320 @@
+package mirror
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/pool"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+type Stats struct {
+	New     int
+	Synced  int
+	Deleted int
+	Failed  int
+}
+
+func (s Stats) Format() string {
+	str := fmt.Sprintf("%v new, %v synced, %v deleted", s.New, s.Synced, s.Deleted)
+
+	if s.Failed > 0 {
+		str += fmt.Sprintf(", %v failed", s.Failed)
+	}
+
+	return str
+}
+
+// listPage gets a page of objects, newest first, after the given ID.
+type listPage[T any] func(after string) (objects []T, lastID string, hasMore bool, err error)
+
+// SyncFiles mirrors new files. See syncList.
+func (m *Mirror) SyncFiles(key string, orgID string, full bool) (*Stats, error) {
+	return syncList(m, filesBucket, full, func(f openai.FileObject) string { return f.ID },
+		func(after string) ([]openai.FileObject, string, bool, error) {
+			page, err := openai.GetFileObjectsPage(key, after, orgID)
+
+			if err != nil {
+				return nil, "", false, err
+			}
+
+			return page.Data, page.LastID, page.HasMore, nil
+		})
+}
+
+// SyncAssts mirrors new assistants. See syncList.
+func (m *Mirror) SyncAssts(key string, orgID string, full bool) (*Stats, error) {
+	return syncList(m, asstsBucket, full, func(a openai.AsstObject) string { return a.ID },
+		func(after string) ([]openai.AsstObject, string, bool, error) {
+			page, err := openai.GetAsstObjectsPage(key, after, orgID)
+
+			if err != nil {
+				return nil, "", false, err
+			}
+
+			return page.Data, page.LastID, page.HasMore, nil
+		})
+}
+
+// syncList pages through a listing, newest first, until it reaches objects created
+// before the newest one the last sync saw. A full sync pages through everything,
+// refreshing every object and marking those no longer listed as deleted.
+func syncList[T interface{ GetCreatedAt() int64 }](m *Mirror, bucket []byte, full bool, id func(T) string, fetch listPage[T]) (*Stats, error) {
+	stats := Stats{}
+	now := time.Now().Unix()
+	highWater := m.highWater(bucket)
+	newest := highWater
+	listed := make(map[string]bool)
+	after := ""
+
+	for {
+		objects, lastID, hasMore, err := fetch(after)
+
+		if err != nil {
+			return nil, err
+		}
+
+		reachedSynced := false
+
+		err = m.db.Update(func(tx *bolt.Tx) error {
+			for _, object := range objects {
+				createdAt := object.GetCreatedAt()
+
+				if !full && createdAt < highWater {
+					reachedSynced = true
+					break
+				}
+
+				existing, err := get[T](tx, bucket, id(object))
+
+				if err != nil {
+					return err
+				}
+
+				if existing == nil {
+					stats.New += 1
+				} else {
+					stats.Synced += 1
+				}
+
+				err = put(tx, bucket, id(object), &Entry[T]{Object: object, SyncedAt: now})
+
+				if err != nil {
+					return err
+				}
+
+				listed[id(object)] = true
+				newest = max(newest, createdAt)
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if reachedSynced || !hasMore || lastID == "" {
+			break
+		}
+
+		after = lastID
+	}
+
+	if full {
+		err := m.db.Update(func(tx *bolt.Tx) error {
+			c := tx.Bucket(bucket).Cursor()
+
+			for k, _ := c.First(); k != nil; k, _ = c.Next() {
+				if listed[string(k)] {
+					continue
+				}
+
+				entry, err := get[T](tx, bucket, string(k))
+
+				if err != nil {
+					return err
+				}
+
+				if entry.DeletedAt != 0 {
+					continue
+				}
+
+				entry.DeletedAt = now
+				stats.Deleted += 1
+
+				err = put(tx, bucket, string(k), entry)
+
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := m.setHighWater(bucket, newest)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// SyncThreads mirrors each thread and the messages added since its last sync, found
+// with the last message synced as the cursor. A full sync refetches every message.
+// Threads the API no longer has are marked deleted; other failures are reported and
+// skipped.
+func (m *Mirror) SyncThreads(key string, threadIDs []string, orgID string, full bool, concurrency int) (*Stats, error) {
+	stats := Stats{}
+	now := time.Now().Unix()
+	var lock sync.Mutex
+	var syncErr error
+
+	count := func(field *int) {
+		lock.Lock()
+		*field += 1
+		lock.Unlock()
+	}
+
+	pool.Run(len(threadIDs), concurrency, func(i int) {
+		threadID := threadIDs[i]
+
+		var existing *Entry[openai.Thread]
+
+		err := m.db.View(func(tx *bolt.Tx) error {
+			var err error
+			existing, err = get[openai.Thread](tx, threadsBucket, threadID)
+			return err
+		})
+
+		if err != nil {
+			lock.Lock()
+			syncErr = err
+			lock.Unlock()
+			return
+		}
+
+		thread, err := openai.GetThread(key, threadID, orgID)
+
+		if err != nil && strings.Contains(err.Error(), "Status: (404)") && existing != nil {
+			if existing.DeletedAt != 0 {
+				return
+			}
+
+			existing.DeletedAt = now
+			err = m.db.Update(func(tx *bolt.Tx) error {
+				return put(tx, threadsBucket, threadID, existing)
+			})
+
+			if err != nil {
+				lock.Lock()
+				syncErr = err
+				lock.Unlock()
+				return
+			}
+
+			count(&stats.Deleted)
+			return
+		}
+
+		if err != nil {
+			fmt.Println(err)
+			count(&stats.Failed)
+			return
+		}
+
+		cursor := ""
+
+		if existing != nil && !full {
+			cursor = existing.Cursor
+		}
+
+		messages := []openai.Message{}
+		after := cursor
+
+		for {
+			page, err := openai.GetThreadMessagesPage(key, threadID, after, orgID)
+
+			if err != nil {
+				fmt.Println(err)
+				count(&stats.Failed)
+				return
+			}
+
+			messages = append(messages, page.Data...)
+
+			if !page.HasMore || page.LastID == "" {
+				break
+			}
+
+			after = page.LastID
+		}
+
+		if len(messages) > 0 {
+			cursor = messages[len(messages)-1].ID
+		}
+
+		err = m.db.Update(func(tx *bolt.Tx) error {
+			if full {
+				err := tx.Bucket(messagesBucket).DeleteBucket([]byte(threadID))
+
+				if err != nil && err != bolt.ErrBucketNotFound {
+					return err
+				}
+			}
+
+			threadMessages, err := tx.Bucket(messagesBucket).CreateBucketIfNotExists([]byte(threadID))
+
+			if err != nil {
+				return err
+			}
+
+			for _, message := range messages {
+				data, err := json.Marshal(message)
+
+				if err != nil {
+					return err
+				}
+
+				err = threadMessages.Put([]byte(message.ID), data)
+
+				if err != nil {
+					return err
+				}
+			}
+
+			return put(tx, threadsBucket, threadID, &Entry[openai.Thread]{Object: *thread, Cursor: cursor, SyncedAt: now})
+		})
+
+		if err != nil {
+			lock.Lock()
+			syncErr = err
+			lock.Unlock()
+			return
+		}
+
+		if existing == nil {
+			count(&stats.New)
+		} else {
+			count(&stats.Synced)
+		}
+	})
+
+	if syncErr != nil {
+		return nil, syncErr
+	}
+
+	return &stats, nil
+}
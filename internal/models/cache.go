@@ -0,0 +1,188 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/jackitaliano/oait/internal/openai"
+)
+
+// MaxAge is how long a cached model list is trusted before checks refetch it.
+const MaxAge = 24 * time.Hour
+
+type Cache struct {
+	KeyID     string         `json:"key_id"`
+	OrgID     string         `json:"org_id,omitempty"`
+	ProjectID string         `json:"project_id,omitempty"`
+	FetchedAt int64          `json:"fetched_at"`
+	Models    []openai.Model `json:"models"`
+}
+
+// Fetch lists the models available to the key, org and project, sorted by ID, and
+// caches them (unless there are none, which is more likely a bad key than true).
+func Fetch(key string, orgID string) ([]openai.Model, error) {
+	modelsResponse, err := openai.GetModels(key, orgID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	models := modelsResponse.Data
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].ID < models[j].ID
+	})
+
+	if len(models) < 1 {
+		return models, nil
+	}
+
+	cache := Cache{KeyID: keyID(key), OrgID: orgID, ProjectID: openai.Project(), FetchedAt: time.Now().Unix(), Models: models}
+	err = save(&cache)
+
+	if err != nil {
+		fmt.Printf("WARNING: %v\n", err)
+	}
+
+	return models, nil
+}
+
+// Load reads the cached model list for the key, org and project.
+func Load(key string, orgID string) (*Cache, error) {
+	fileName, err := cachePath(keyID(key), orgID, openai.Project())
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = errors.New("No cached models: run `oait models list` while online first")
+			return nil, err
+		}
+
+		err = errors.New("Failed reading model cache: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	var cache Cache
+	err = json.Unmarshal(data, &cache)
+
+	if err != nil {
+		err = errors.New("Failed parsing model cache: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	return &cache, nil
+}
+
+// Age is how long ago the cache was fetched.
+func (c *Cache) Age() time.Duration {
+	return time.Since(time.Unix(c.FetchedAt, 0))
+}
+
+// Unknown looks up each model ID, using a fresh cache when there is one and falling
+// back to a stale cache when the API can't be reached. It returns the IDs not found,
+// and the age of the stale cache if one was used.
+func Unknown(key string, orgID string, modelIDs []string) ([]string, time.Duration, error) {
+	var models []openai.Model
+	var staleAge time.Duration
+	cache, cacheErr := Load(key, orgID)
+
+	if cacheErr == nil && cache.Age() < MaxAge {
+		models = cache.Models
+	} else {
+		fetched, err := Fetch(key, orgID)
+
+		if err != nil {
+			if cacheErr != nil {
+				return nil, 0, err
+			}
+
+			staleAge = cache.Age()
+			fetched = cache.Models
+		}
+
+		models = fetched
+	}
+
+	available := make(map[string]bool, len(models))
+	for _, model := range models {
+		available[model.ID] = true
+	}
+
+	unknown := []string{}
+
+	for _, modelID := range modelIDs {
+		if !available[modelID] {
+			unknown = append(unknown, modelID)
+		}
+	}
+
+	return unknown, staleAge, nil
+}
+
+func save(cache *Cache) error {
+	fileName, err := cachePath(cache.KeyID, cache.OrgID, cache.ProjectID)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(fileName), 0755)
+
+	if err == nil {
+		err = os.WriteFile(fileName, data, 0644)
+	}
+
+	if err != nil {
+		err = errors.New("Failed writing model cache: " + fileName + ". Error: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// keyID fingerprints the key, so each key has its own cache without storing the key.
+func keyID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// cachePath is the cache of a key, org and project: a list fetched for one doesn't
+// hold for another.
+func cachePath(keyID string, orgID string, projectID string) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+
+	if err != nil {
+		err = errors.New("Failed to locate model cache: " + err.Error())
+		return "", err
+	}
+
+	fileName := "models-" + keyID
+
+	if orgID != "" {
+		fileName += "-" + orgID
+	}
+
+	if projectID != "" {
+		fileName += "-" + projectID
+	}
+
+	return filepath.Join(cacheDir, "oait", fileName+".json"), nil
+}
@@ -0,0 +1,172 @@
+package moderate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/pool"
+)
+
+const (
+	// DefaultModel is the moderation model used when none is given.
+	DefaultModel = "omni-moderation-latest"
+
+	// Inputs sent per moderation request, and requests in flight at once.
+	batchSize   = 32
+	concurrency = 4
+)
+
+// Item is one piece of text to screen, with where it came from.
+type Item struct {
+	ThreadID  string `json:"thread_id,omitempty"`
+	MessageID string `json:"message_id,omitempty"`
+	Role      string `json:"role,omitempty"`
+	Text      string `json:"text"`
+}
+
+type Result struct {
+	Item
+	Flagged    bool               `json:"flagged"`
+	Categories []string           `json:"categories,omitempty"`
+	Scores     map[string]float64 `json:"scores,omitempty"`
+}
+
+// FromThreads makes an item of each message with text, oldest first per thread.
+func FromThreads(threads []openai.Messages) []Item {
+	items := []Item{}
+
+	for _, thread := range threads {
+		for i := len(thread.Messages) - 1; i >= 0; i-- { // Messages come newest first
+			msg := thread.Messages[i]
+			text := msg.GetText()
+
+			if text == "" {
+				continue
+			}
+
+			items = append(items, Item{ThreadID: msg.ThreadID, MessageID: msg.ID, Role: msg.Role, Text: text})
+		}
+	}
+
+	return items
+}
+
+// FromTexts makes an item of each raw text.
+func FromTexts(texts []string) []Item {
+	items := make([]Item, len(texts))
+
+	for i, text := range texts {
+		items[i] = Item{Text: text}
+	}
+
+	return items
+}
+
+// Check runs every item through the moderation endpoint, in batches, returning a
+// result per item in order. Only the scores of flagged categories are kept.
+func Check(key string, items []Item, model string, orgID string) ([]Result, error) {
+	results := make([]Result, len(items))
+	numBatches := (len(items) + batchSize - 1) / batchSize
+	errs := make([]error, numBatches)
+
+	pool.Run(numBatches, concurrency, func(b int) {
+		start := b * batchSize
+		end := min(start+batchSize, len(items))
+
+		moderationReq := openai.ModerationRequest{Model: model}
+		for _, item := range items[start:end] {
+			moderationReq.Input = append(moderationReq.Input, item.Text)
+		}
+
+		moderationRes, err := openai.NewModeration(key, &moderationReq, orgID)
+
+		if err != nil {
+			errs[b] = err
+			return
+		}
+
+		if len(moderationRes.Results) != end-start {
+			errMsg := fmt.Sprintf("Moderation returned %v results for %v inputs", len(moderationRes.Results), end-start)
+			errs[b] = errors.New(errMsg)
+			return
+		}
+
+		for i, moderationResult := range moderationRes.Results {
+			results[start+i] = toResult(items[start+i], moderationResult)
+		}
+	})
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+func toResult(item Item, moderationResult openai.ModerationResult) Result {
+	result := Result{Item: item, Flagged: moderationResult.Flagged}
+
+	for category, flagged := range moderationResult.Categories {
+		if !flagged {
+			continue
+		}
+
+		result.Categories = append(result.Categories, category)
+
+		if result.Scores == nil {
+			result.Scores = make(map[string]float64)
+		}
+		result.Scores[category] = moderationResult.CategoryScores[category]
+	}
+
+	sort.Strings(result.Categories)
+
+	return result
+}
+
+// Flagged keeps the flagged results, and with categories given, only those flagged
+// in at least one of them.
+func Flagged(results []Result, categories []string) []Result {
+	flagged := []Result{}
+
+	for _, result := range results {
+		if !result.Flagged {
+			continue
+		}
+
+		if len(categories) > 0 && !hasCategory(result, categories) {
+			continue
+		}
+
+		flagged = append(flagged, result)
+	}
+
+	return flagged
+}
+
+// FlaggedThreads is the set of thread IDs with a flagged message.
+func FlaggedThreads(results []Result, categories []string) map[string]bool {
+	threadIDs := make(map[string]bool)
+
+	for _, result := range Flagged(results, categories) {
+		threadIDs[result.ThreadID] = true
+	}
+
+	return threadIDs
+}
+
+func hasCategory(result Result, categories []string) bool {
+	for _, category := range categories {
+		for _, flaggedCategory := range result.Categories {
+			if flaggedCategory == category {
+				return true
+			}
+		}
+	}
+
+	return false
+}
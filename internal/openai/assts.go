@@ -1,84 +1,98 @@
 package openai
 
 import (
+	"errors"
 	"fmt"
+
+	"github.com/jackitaliano/oait/internal/pool"
 )
 
-func retrieveAsst(c chan AsstObject, key string, fileID string, orgID string) {
+func RetrieveAssts(key string, asstIDs []string, orgID string) *[]AsstObject {
+	assts := make([]AsstObject, len(asstIDs))
 
-	asstObject, err := GetAsstObject(key, fileID, orgID)
+	pool.Run(len(asstIDs), pool.DefaultLimit(), func(i int) {
+		asstObject, err := GetAsstObject(key, asstIDs[i], orgID)
 
-	if err != nil {
-		fmt.Println(err)
-		c <- AsstObject{}
-		return
-	}
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		assts[i] = *asstObject
+	})
 
-	c <- *asstObject
+	return &assts
 }
 
-func RetrieveAssts(key string, threadIDs []string, orgID string) *[]AsstObject {
-	c := make(chan AsstObject, len(threadIDs))
+// RetrieveAllAssts pages through every assistant, newest first.
+func RetrieveAllAssts(key string, orgID string) (*[]AsstObject, error) {
+	assts := []AsstObject{}
+	after := ""
 
-	for _, threadID := range threadIDs {
-		go retrieveAsst(c, key, threadID, orgID)
-	}
+	for {
+		page, err := GetAsstObjectsPage(key, after, orgID)
 
-	files := make([]AsstObject, len(threadIDs))
-	for i := range files {
-		files[i] = <-c
-	}
+		if err != nil {
+			return nil, err
+		}
 
-	return &files
-}
+		assts = append(assts, page.Data...)
 
-func RetrieveAllAssts(key string, orgID string) (*[]AsstObject, error) {
-	files, err := GetAllAsstObjects(key, orgID)
+		if !page.HasMore {
+			return &assts, nil
+		}
 
-	if err != nil {
-		return nil, err
-	}
+		if page.LastID == "" {
+			errMsg := fmt.Sprintf("Failed paging assts (no cursor after %v assts)", len(assts))
+			err = errors.New(errMsg)
+			return nil, err
+		}
 
-	return &files.Data, nil
+		after = page.LastID
+	}
 }
 
-func deleteAsst(c chan *AsstDeleteResponse, key string, asstID string, orgID string) {
+func DeleteAssts(key string, asstIDs []string, orgID string) int {
+	deleted := make([]bool, len(asstIDs))
 
-	deleteResponse, err := DeleteAsst(key, asstID, orgID)
+	pool.Run(len(asstIDs), pool.DefaultLimit(), func(i int) {
+		deleteResponse, err := DeleteAsst(key, asstIDs[i], orgID)
 
-	if err != nil {
-		fmt.Println(err)
-		c <- nil
-		return
-	}
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
 
-	c <- deleteResponse
+		deleted[i] = deleteResponse.Deleted
+	})
+
+	return countTrue(deleted)
 }
 
-func DeleteAssts(key string, fileIDs []string, orgID string) int {
-	c := make(chan *AsstDeleteResponse, len(fileIDs))
+func CreateAssistant(key string, createdAsst *CreatedAssistant, orgID string) (*AsstObject, error) {
+	asst, err := NewAssistant(key, createdAsst, orgID)
+
+	return asst, err
+}
 
-	for _, threadID := range fileIDs {
-		go deleteAsst(c, key, threadID, orgID)
+func UpdateAssts(key string, modifiedAssts map[string]*ModifiedAssistant, orgID string) int {
+	asstIDs := make([]string, 0, len(modifiedAssts))
+	for asstID := range modifiedAssts {
+		asstIDs = append(asstIDs, asstID)
 	}
 
-	results := make([]*AsstDeleteResponse, len(fileIDs))
-	numDeleted := 0
+	updated := make([]bool, len(asstIDs))
 
-	for i := range results {
-		res := <-c
-		results[i] = res
+	pool.Run(len(asstIDs), pool.DefaultLimit(), func(i int) {
+		_, err := ModifyAssistant(key, asstIDs[i], modifiedAssts[asstIDs[i]], orgID)
 
-		if res.Deleted {
-			numDeleted += 1
+		if err != nil {
+			fmt.Println(err)
+			return
 		}
-	}
 
-	return numDeleted
-}
+		updated[i] = true
+	})
 
-func CreateAssistant(key string, createdAsst *CreatedAssistant, orgID string) (*AsstObject, error) {
-	asst, err := NewAssistant(key, createdAsst, orgID)
-
-	return asst, err
+	return countTrue(updated)
 }
@@ -12,8 +12,10 @@ import (
 )
 
 type AsstObjectsResponse struct {
-	Data   []AsstObject `json:"data"`
-	Object string       `json:"object"`
+	Data    []AsstObject `json:"data"`
+	Object  string       `json:"object"`
+	LastID  string       `json:"last_id,omitempty"`
+	HasMore bool         `json:"has_more,omitempty"`
 }
 
 type AsstDeleteResponse struct {
@@ -32,21 +34,36 @@ type AsstObject struct {
 	Model         string                         `json:"model"`
 	Tools         []Tool                         `json:"tools"`
 	ToolResources map[string]map[string][]string `json:"tool_resources,omitempty"`
-	ResFormat     string                         `json:"response_format"`
+	ResFormat     *ResponseFormat                `json:"response_format,omitempty"`
 	Temp          float64                        `json:"temperature"`
 	TopP          float64                        `json:"top_p"`
+	Metadata      map[string]string              `json:"metadata,omitempty"`
 }
 
 type CreatedAssistant struct {
-	Name          string              `json:"name,omitempty"`
-	Description   string              `json:"description,omitempty"`
-	Instructions  string              `json:"instructions,omitempty"`
-	Model         string              `json:"model"`
-	Tools         []Tool              `json:"tools,omitempty"`
-	ToolResources map[string][]string `json:"tool_resources,omitempty"`
-	ResFormat     string              `json:"response_format,omitempty"`
-	Temp          float64             `json:"temperature,omitempty"`
-	TopP          float64             `json:"top_p,omitempty"`
+	Name          string                         `json:"name,omitempty"`
+	Description   string                         `json:"description,omitempty"`
+	Instructions  string                         `json:"instructions,omitempty"`
+	Model         string                         `json:"model"`
+	Tools         []Tool                         `json:"tools,omitempty"`
+	ToolResources map[string]map[string][]string `json:"tool_resources,omitempty"`
+	ResFormat     *ResponseFormat                `json:"response_format,omitempty"`
+	Temp          *float64                       `json:"temperature,omitempty"`
+	TopP          *float64                       `json:"top_p,omitempty"`
+	Metadata      map[string]string              `json:"metadata,omitempty"`
+}
+
+type ModifiedAssistant struct {
+	Name          *string                        `json:"name,omitempty"`
+	Description   *string                        `json:"description,omitempty"`
+	Instructions  *string                        `json:"instructions,omitempty"`
+	Model         *string                        `json:"model,omitempty"`
+	Tools         *[]Tool                        `json:"tools,omitempty"`
+	ToolResources map[string]map[string][]string `json:"tool_resources,omitempty"`
+	ResFormat     *ResponseFormat                `json:"response_format,omitempty"`
+	Temp          *float64                       `json:"temperature,omitempty"`
+	TopP          *float64                       `json:"top_p,omitempty"`
+	Metadata      map[string]string              `json:"metadata,omitempty"`
 }
 
 type Tool struct {
@@ -54,21 +71,64 @@ type Tool struct {
 	Function *Function `json:"function,omitempty"`
 }
 
+// Function keeps Parameters as raw JSON Schema so nested objects, arrays, enums
+// etc. round-trip unchanged.
 type Function struct {
-	Name        string             `json:"name"`
-	Description string             `json:"description"`
-	Parameters  FunctionParameters `json:"parameters"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+	Strict      *bool           `json:"strict,omitempty"`
 }
 
-type FunctionParameters struct {
-	Type       string              `json:"type"`
-	Properties map[string]Property `json:"properties"`
-	Required   []string            `json:"required"`
+// ResponseFormat is either a bare "auto" or an object such as {"type": "json_object"}
+// or {"type": "json_schema", "json_schema": {...}}.
+type ResponseFormat struct {
+	Type       string      `json:"type"`
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
 }
 
-type Property struct {
-	Type        string `json:"string"`
-	Description string `json:"description"`
+type JSONSchema struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Schema      json.RawMessage `json:"schema,omitempty"`
+	Strict      *bool           `json:"strict,omitempty"`
+}
+
+func (r ResponseFormat) MarshalJSON() ([]byte, error) {
+	if r.Type == "auto" {
+		return json.Marshal(r.Type)
+	}
+
+	type responseFormat ResponseFormat // Without the MarshalJSON method
+
+	return json.Marshal(responseFormat(r))
+}
+
+func (r *ResponseFormat) UnmarshalJSON(data []byte) error {
+	var formatType string
+
+	if json.Unmarshal(data, &formatType) == nil {
+		*r = ResponseFormat{Type: formatType}
+		return nil
+	}
+
+	type responseFormat ResponseFormat // Without the UnmarshalJSON method
+
+	return json.Unmarshal(data, (*responseFormat)(r))
+}
+
+func (r *ResponseFormat) String() string {
+	if r == nil {
+		return ""
+	}
+
+	if r.JSONSchema == nil {
+		return r.Type
+	}
+
+	b, _ := json.Marshal(r)
+
+	return string(b)
 }
 
 func (a AsstObject) GetCreatedAt() int64 {
@@ -79,6 +139,27 @@ func (a AsstObject) GetName() string {
 	return a.Name
 }
 
+func (a AsstObject) GetMetadata() map[string]string {
+	return a.Metadata
+}
+
+func (a AsstObject) ToCreated() *CreatedAssistant {
+	createdAsst := CreatedAssistant{
+		Name:          a.Name,
+		Description:   a.Description,
+		Instructions:  a.Instructions,
+		Model:         a.Model,
+		Tools:         a.Tools,
+		ToolResources: a.ToolResources,
+		ResFormat:     a.ResFormat,
+		Temp:          &a.Temp,
+		TopP:          &a.TopP,
+		Metadata:      a.Metadata,
+	}
+
+	return &createdAsst
+}
+
 func (a AsstObjectsResponse) GetLen() int {
 	return len(a.Data)
 }
@@ -97,14 +178,9 @@ func GetAsstObject(key string, asstID string, orgID string) (*AsstObject, error)
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+key)
-	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, key, orgID, "application/json")
 	req.Header.Set("Openai-Beta", "assistants=v2")
 
-	if orgID != "" {
-		req.Header.Set("Openai-Organization", orgID)
-	}
-
 	resBody, err := request.Process[AsstObject](req)
 
 	if err != nil {
@@ -128,14 +204,40 @@ func GetAllAsstObjects(key string, orgID string) (*AsstObjectsResponse, error) {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+key)
-	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, key, orgID, "application/json")
 	req.Header.Set("Openai-Beta", "assistants=v2")
 
-	if orgID != "" {
-		req.Header.Set("Openai-Organization", orgID)
+	resBody, err := request.Process[AsstObjectsResponse](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
+
+// GetAsstObjectsPage gets a page of assistants, newest first, after the given ID (if any).
+func GetAsstObjectsPage(key string, after string, orgID string) (*AsstObjectsResponse, error) {
+	url := "https://api.openai.com/v1/assistants?limit=100&order=desc"
+
+	if after != "" {
+		url += "&after=" + after
 	}
 
+	method := "GET"
+	var reqBody io.Reader = nil
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+	req.Header.Set("Openai-Beta", "assistants=v2")
+
 	resBody, err := request.Process[AsstObjectsResponse](req)
 
 	if err != nil {
@@ -164,14 +266,40 @@ func NewAssistant(key string, asst *CreatedAssistant, orgID string) (*AsstObject
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+key)
-	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, key, orgID, "application/json")
 	req.Header.Set("Openai-Beta", "assistants=v2")
 
-	if orgID != "" {
-		req.Header.Set("Openai-Organization", orgID)
+	resBody, err := request.Process[AsstObject](req)
+
+	if err != nil {
+		return nil, err
 	}
 
+	return resBody, nil
+}
+
+func ModifyAssistant(key string, asstID string, asst *ModifiedAssistant, orgID string) (*AsstObject, error) {
+	url := fmt.Sprintf("https://api.openai.com/v1/assistants/%v", asstID)
+
+	method := "POST"
+	jsonData, err := json.Marshal(*asst)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBody := bytes.NewReader(jsonData)
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+	req.Header.Set("Openai-Beta", "assistants=v2")
+
 	resBody, err := request.Process[AsstObject](req)
 
 	if err != nil {
@@ -195,14 +323,9 @@ func DeleteAsst(key string, asstID string, orgID string) (*AsstDeleteResponse, e
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+key)
-	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, key, orgID, "application/json")
 	req.Header.Set("Openai-Beta", "assistants=v2")
 
-	if orgID != "" {
-		req.Header.Set("Openai-Organization", orgID)
-	}
-
 	resBody, err := request.Process[AsstDeleteResponse](req)
 
 	if err != nil {
@@ -0,0 +1,49 @@
+package openai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jackitaliano/oait/internal/request"
+)
+
+func TestRetrieveAllAsstsPages(t *testing.T) {
+	pages := map[string]AsstObjectsResponse{
+		"":       {Data: []AsstObject{{ID: "asst_3"}, {ID: "asst_2"}}, LastID: "asst_2", HasMore: true},
+		"asst_2": {Data: []AsstObject{{ID: "asst_1"}}, LastID: "asst_1"},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Query().Get("after")]
+
+		if r.URL.Path != "/v1/assistants" || !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer srv.Close()
+
+	if err := request.SetBaseURL(srv.URL + "/v1"); err != nil {
+		t.Fatal(err)
+	}
+	defer request.SetBaseURL("")
+
+	assts, err := RetrieveAllAssts("sk-test", "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []string{}
+	for _, asst := range *assts {
+		ids = append(ids, asst.ID)
+	}
+
+	if len(ids) != 3 || ids[0] != "asst_3" || ids[2] != "asst_1" {
+		t.Errorf("ids = %v, want asst_3 asst_2 asst_1", ids)
+	}
+}
@@ -0,0 +1,107 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/jackitaliano/oait/internal/request"
+)
+
+type TranscriptionRequest struct {
+	Model                  string
+	Language               string
+	Prompt                 string
+	ResponseFormat         string
+	Temp                   *float64
+	TimestampGranularities []string
+}
+
+type SpeechRequest struct {
+	Model          string   `json:"model"`
+	Input          string   `json:"input"`
+	Voice          string   `json:"voice"`
+	ResponseFormat string   `json:"response_format,omitempty"`
+	Speed          *float64 `json:"speed,omitempty"`
+}
+
+// Transcribe returns the transcript of audio as the raw response body, which is
+// JSON, SRT, VTT or plain text depending on the response format.
+func Transcribe(key string, audio FileUpload, transcriptionReq *TranscriptionRequest, orgID string) ([]byte, error) {
+	url := "https://api.openai.com/v1/audio/transcriptions"
+
+	return postAudioForm(key, url, audio, transcriptionReq, orgID)
+}
+
+// Translate is Transcribe into English. Language and timestamps don't apply.
+func Translate(key string, audio FileUpload, transcriptionReq *TranscriptionRequest, orgID string) ([]byte, error) {
+	url := "https://api.openai.com/v1/audio/translations"
+
+	return postAudioForm(key, url, audio, transcriptionReq, orgID)
+}
+
+func Speak(key string, speechReq *SpeechRequest, orgID string) ([]byte, error) {
+	url := "https://api.openai.com/v1/audio/speech"
+
+	method := "POST"
+	jsonData, err := json.Marshal(*speechReq)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBody := bytes.NewReader(jsonData)
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+
+	resBody, err := request.ProcessRaw(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
+
+func postAudioForm(key string, url string, audio FileUpload, transcriptionReq *TranscriptionRequest, orgID string) ([]byte, error) {
+	files := []multipartFile{{"file", audio}}
+
+	fields := [][2]string{
+		{"model", transcriptionReq.Model},
+		{"language", transcriptionReq.Language},
+		{"prompt", transcriptionReq.Prompt},
+		{"response_format", transcriptionReq.ResponseFormat},
+	}
+
+	if transcriptionReq.Temp != nil {
+		fields = append(fields, [2]string{"temperature", strconv.FormatFloat(*transcriptionReq.Temp, 'f', -1, 64)})
+	}
+
+	for _, granularity := range transcriptionReq.TimestampGranularities {
+		fields = append(fields, [2]string{"timestamp_granularities[]", granularity})
+	}
+
+	req, err := newMultipartRequest(key, url, files, fields, orgID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := request.ProcessRaw(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
@@ -0,0 +1,78 @@
+package openai
+
+import (
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/pool"
+)
+
+var batchDoneStatuses = []string{"completed", "failed", "expired", "cancelled"}
+
+// IsDone reports whether the batch has reached a final status.
+func (b *Batch) IsDone() bool {
+	for _, status := range batchDoneStatuses {
+		if b.Status == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func RetrieveBatches(key string, batchIDs []string, orgID string) *[]Batch {
+	batches := make([]Batch, len(batchIDs))
+
+	pool.Run(len(batchIDs), pool.DefaultLimit(), func(i int) {
+		batch, err := GetBatch(key, batchIDs[i], orgID)
+
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		batches[i] = *batch
+	})
+
+	return &batches
+}
+
+// RetrieveAllBatches pages through every batch, newest first.
+func RetrieveAllBatches(key string, orgID string) ([]Batch, error) {
+	batches := []Batch{}
+	after := ""
+
+	for {
+		batchesResponse, err := GetBatches(key, after, orgID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		batches = append(batches, batchesResponse.Data...)
+
+		if !batchesResponse.HasMore || batchesResponse.LastID == "" {
+			break
+		}
+
+		after = batchesResponse.LastID
+	}
+
+	return batches, nil
+}
+
+func CancelBatches(key string, batchIDs []string, orgID string) int {
+	cancelled := make([]bool, len(batchIDs))
+
+	pool.Run(len(batchIDs), pool.DefaultLimit(), func(i int) {
+		_, err := CancelBatch(key, batchIDs[i], orgID)
+
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		cancelled[i] = true
+	})
+
+	return countTrue(cancelled)
+}
@@ -0,0 +1,185 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/jackitaliano/oait/internal/request"
+)
+
+type Batch struct {
+	ID               string            `json:"id"`
+	Object           string            `json:"object"`
+	Endpoint         string            `json:"endpoint"`
+	Errors           *BatchErrors      `json:"errors,omitempty"`
+	InputFileID      string            `json:"input_file_id"`
+	CompletionWindow string            `json:"completion_window"`
+	Status           string            `json:"status"`
+	OutputFileID     string            `json:"output_file_id,omitempty"`
+	ErrorFileID      string            `json:"error_file_id,omitempty"`
+	CreatedAt        int64             `json:"created_at"`
+	InProgressAt     int64             `json:"in_progress_at,omitempty"`
+	ExpiresAt        int64             `json:"expires_at,omitempty"`
+	FinalizingAt     int64             `json:"finalizing_at,omitempty"`
+	CompletedAt      int64             `json:"completed_at,omitempty"`
+	FailedAt         int64             `json:"failed_at,omitempty"`
+	ExpiredAt        int64             `json:"expired_at,omitempty"`
+	CancellingAt     int64             `json:"cancelling_at,omitempty"`
+	CancelledAt      int64             `json:"cancelled_at,omitempty"`
+	RequestCounts    BatchCounts       `json:"request_counts"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+type BatchErrors struct {
+	Object string       `json:"object"`
+	Data   []BatchError `json:"data"`
+}
+
+type BatchError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Param   string `json:"param,omitempty"`
+	Line    *int   `json:"line,omitempty"`
+}
+
+type BatchCounts struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+}
+
+type CreatedBatch struct {
+	InputFileID      string            `json:"input_file_id"`
+	Endpoint         string            `json:"endpoint"`
+	CompletionWindow string            `json:"completion_window"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+type BatchesResponse struct {
+	Object  string  `json:"object"`
+	Data    []Batch `json:"data"`
+	FirstID string  `json:"first_id"`
+	LastID  string  `json:"last_id"`
+	HasMore bool    `json:"has_more"`
+}
+
+func (b Batch) GetCreatedAt() int64 {
+	return b.CreatedAt
+}
+
+func (b Batch) GetMetadata() map[string]string {
+	return b.Metadata
+}
+
+func GetBatch(key string, batchID string, orgID string) (*Batch, error) {
+	url := fmt.Sprintf("https://api.openai.com/v1/batches/%v", batchID)
+
+	method := "GET"
+	var reqBody io.Reader = nil
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+
+	resBody, err := request.Process[Batch](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
+
+func GetBatches(key string, after string, orgID string) (*BatchesResponse, error) {
+	url := "https://api.openai.com/v1/batches?limit=100"
+
+	if after != "" {
+		url += "&after=" + after
+	}
+
+	method := "GET"
+	var reqBody io.Reader = nil
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+
+	resBody, err := request.Process[BatchesResponse](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
+
+func NewBatch(key string, batch *CreatedBatch, orgID string) (*Batch, error) {
+	url := "https://api.openai.com/v1/batches"
+
+	method := "POST"
+	jsonData, err := json.Marshal(*batch)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBody := bytes.NewReader(jsonData)
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+
+	resBody, err := request.Process[Batch](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
+
+func CancelBatch(key string, batchID string, orgID string) (*Batch, error) {
+	url := fmt.Sprintf("https://api.openai.com/v1/batches/%v/cancel", batchID)
+
+	method := "POST"
+	var reqBody io.Reader = nil
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+
+	resBody, err := request.Process[Batch](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
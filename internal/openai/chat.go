@@ -0,0 +1,52 @@
+package openai
+
+// CompleteChat streams a completion, calling onContent with each piece of reply text
+// as it arrives, and returns the assembled reply (with any tool calls).
+func CompleteChat(key string, chatReq *ChatRequest, orgID string, onContent func(content string)) (*ChatMessage, error) {
+	reply := ChatMessage{Role: "assistant"}
+
+	err := StreamChatCompletion(key, chatReq, orgID, func(chunk *ChatCompletion) error {
+		if len(chunk.Choices) < 1 {
+			return nil
+		}
+
+		delta := chunk.Choices[0].Delta
+
+		if delta.Content != "" {
+			reply.Content += delta.Content
+			onContent(delta.Content)
+		}
+
+		for _, toolCall := range delta.ToolCalls {
+			index := len(reply.ToolCalls)
+			if toolCall.Index != nil {
+				index = *toolCall.Index
+			}
+
+			for len(reply.ToolCalls) <= index {
+				reply.ToolCalls = append(reply.ToolCalls, ToolCall{})
+			}
+
+			replyCall := &reply.ToolCalls[index]
+
+			if toolCall.ID != "" {
+				replyCall.ID = toolCall.ID
+			}
+
+			if toolCall.Type != "" {
+				replyCall.Type = toolCall.Type
+			}
+
+			replyCall.Function.Name += toolCall.Function.Name
+			replyCall.Function.Arguments += toolCall.Function.Arguments
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &reply, nil
+}
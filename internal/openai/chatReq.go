@@ -0,0 +1,129 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jackitaliano/oait/internal/request"
+)
+
+type ChatMessage struct {
+	Role       string     `json:"role" yaml:"role"`
+	Content    string     `json:"content,omitempty" yaml:"content,omitempty"`
+	Name       string     `json:"name,omitempty" yaml:"name,omitempty"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty" yaml:"tool_calls,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty" yaml:"tool_call_id,omitempty"`
+}
+
+type ToolCall struct {
+	Index    *int             `json:"index,omitempty" yaml:"-"` // Only set on stream deltas
+	ID       string           `json:"id,omitempty" yaml:"id,omitempty"`
+	Type     string           `json:"type,omitempty" yaml:"type,omitempty"`
+	Function ToolCallFunction `json:"function" yaml:"function"`
+}
+
+type ToolCallFunction struct {
+	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
+	Arguments string `json:"arguments" yaml:"arguments"`
+}
+
+type ChatRequest struct {
+	Model          string          `json:"model"`
+	Messages       []ChatMessage   `json:"messages"`
+	Temp           *float64        `json:"temperature,omitempty"`
+	MaxTokens      *int            `json:"max_completion_tokens,omitempty"`
+	Tools          []Tool          `json:"tools,omitempty"`
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+	Seed           *int            `json:"seed,omitempty"`
+	Stream         bool            `json:"stream,omitempty"`
+}
+
+type ChatCompletion struct {
+	ID      string       `json:"id"`
+	Object  string       `json:"object"`
+	Created int64        `json:"created"`
+	Model   string       `json:"model"`
+	Choices []ChatChoice `json:"choices"`
+	Usage   *Usage       `json:"usage,omitempty"`
+}
+
+type ChatChoice struct {
+	Index        int         `json:"index"`
+	Message      ChatMessage `json:"message"`
+	Delta        ChatMessage `json:"delta"`
+	FinishReason string      `json:"finish_reason"`
+}
+
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+func NewChatCompletion(key string, chatReq *ChatRequest, orgID string) (*ChatCompletion, error) {
+	req, err := newChatRequest(key, chatReq, orgID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := request.Process[ChatCompletion](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
+
+// StreamChatCompletion calls onChunk with each chunk of a streamed completion.
+func StreamChatCompletion(key string, chatReq *ChatRequest, orgID string, onChunk func(chunk *ChatCompletion) error) error {
+	streamReq := *chatReq
+	streamReq.Stream = true
+
+	req, err := newChatRequest(key, &streamReq, orgID)
+
+	if err != nil {
+		return err
+	}
+
+	return request.Stream(req, func(data []byte) error {
+		var chunk ChatCompletion
+		err := json.Unmarshal(data, &chunk)
+
+		if err != nil {
+			errMsg := fmt.Sprintf("Error reading stream chunk: %v", err)
+			err = errors.New(errMsg)
+			return err
+		}
+
+		return onChunk(&chunk)
+	})
+}
+
+func newChatRequest(key string, chatReq *ChatRequest, orgID string) (*http.Request, error) {
+	url := "https://api.openai.com/v1/chat/completions"
+
+	method := "POST"
+	jsonData, err := json.Marshal(*chatReq)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBody := bytes.NewReader(jsonData)
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+
+	return req, nil
+}
@@ -0,0 +1,61 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jackitaliano/oait/internal/request"
+)
+
+type EmbeddingRequest struct {
+	Model          string   `json:"model"`
+	Input          []string `json:"input"`
+	Dimensions     *int     `json:"dimensions,omitempty"`
+	EncodingFormat string   `json:"encoding_format,omitempty"`
+}
+
+type EmbeddingsResponse struct {
+	Object string      `json:"object"`
+	Data   []Embedding `json:"data"`
+	Model  string      `json:"model"`
+	Usage  *Usage      `json:"usage,omitempty"`
+}
+
+type Embedding struct {
+	Object    string    `json:"object"`
+	Index     int       `json:"index"`
+	Embedding []float32 `json:"embedding"`
+}
+
+func NewEmbeddings(key string, embeddingReq *EmbeddingRequest, orgID string) (*EmbeddingsResponse, error) {
+	url := "https://api.openai.com/v1/embeddings"
+
+	method := "POST"
+	jsonData, err := json.Marshal(*embeddingReq)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBody := bytes.NewReader(jsonData)
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+
+	resBody, err := request.Process[EmbeddingsResponse](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
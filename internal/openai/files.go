@@ -2,67 +2,40 @@ package openai
 
 import (
 	"fmt"
-)
-
-func deleteFile(c chan *FileDeleteResponse, key string, fileID string, orgID string) {
-
-	deleteResponse, err := DeleteFile(key, fileID, orgID)
-
-	if err != nil {
-		fmt.Println(err)
-		c <- nil
-		return
-	}
 
-	c <- deleteResponse
-}
+	"github.com/jackitaliano/oait/internal/pool"
+)
 
 func DeleteFiles(key string, fileIDs []string, orgID string) int {
-	c := make(chan *FileDeleteResponse, len(fileIDs))
-
-	for _, threadID := range fileIDs {
-		go deleteFile(c, key, threadID, orgID)
-	}
-
-	results := make([]*FileDeleteResponse, len(fileIDs))
-	numDeleted := 0
+	deleted := make([]bool, len(fileIDs))
 
-	for i := range results {
-		res := <-c
-		results[i] = res
+	pool.Run(len(fileIDs), pool.DefaultLimit(), func(i int) {
+		deleteResponse, err := DeleteFile(key, fileIDs[i], orgID)
 
-		if res.Deleted {
-			numDeleted += 1
+		if err != nil {
+			fmt.Println(err)
+			return
 		}
-	}
-
-	return numDeleted
-}
 
-func retrieveFile(c chan FileObject, key string, fileID string, orgID string) {
+		deleted[i] = deleteResponse.Deleted
+	})
 
-	fileObject, err := GetFileObject(key, fileID, orgID)
-
-	if err != nil {
-		fmt.Println(err)
-		c <- FileObject{}
-		return
-	}
-
-	c <- *fileObject
+	return countTrue(deleted)
 }
 
-func RetrieveFiles(key string, threadIDs []string, orgID string) *[]FileObject {
-	c := make(chan FileObject, len(threadIDs))
+func RetrieveFiles(key string, fileIDs []string, orgID string) *[]FileObject {
+	files := make([]FileObject, len(fileIDs))
 
-	for _, threadID := range threadIDs {
-		go retrieveFile(c, key, threadID, orgID)
-	}
+	pool.Run(len(fileIDs), pool.DefaultLimit(), func(i int) {
+		fileObject, err := GetFileObject(key, fileIDs[i], orgID)
 
-	files := make([]FileObject, len(threadIDs))
-	for i := range files {
-		files[i] = <-c
-	}
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		files[i] = *fileObject
+	})
 
 	return &files
 }
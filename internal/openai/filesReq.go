@@ -1,17 +1,21 @@
 package openai
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 
 	"github.com/jackitaliano/oait/internal/request"
 )
 
 type FileObjectsResponse struct {
-	Data   []FileObject `json:"data"`
-	Object string       `json:"object"`
+	Data    []FileObject `json:"data"`
+	Object  string       `json:"object"`
+	LastID  string       `json:"last_id,omitempty"`
+	HasMore bool         `json:"has_more,omitempty"`
 }
 
 type FileDeleteResponse struct {
@@ -52,12 +56,7 @@ func GetFileObject(key string, fileID string, orgID string) (*FileObject, error)
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+key)
-	req.Header.Set("Content-Type", "application/json")
-
-	if orgID != "" {
-		req.Header.Set("Openai-Organization", orgID)
-	}
+	setHeaders(req, key, orgID, "application/json")
 
 	resBody, err := request.Process[FileObject](req)
 
@@ -82,13 +81,38 @@ func GetAllFileObjects(key string, orgID string) (*FileObjectsResponse, error) {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+key)
-	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, key, orgID, "application/json")
+
+	resBody, err := request.Process[FileObjectsResponse](req)
 
-	if orgID != "" {
-		req.Header.Set("Openai-Organization", orgID)
+	if err != nil {
+		return nil, err
 	}
 
+	return resBody, nil
+}
+
+// GetFileObjectsPage gets a page of files, newest first, after the given ID (if any).
+func GetFileObjectsPage(key string, after string, orgID string) (*FileObjectsResponse, error) {
+	url := "https://api.openai.com/v1/files?limit=10000&order=desc"
+
+	if after != "" {
+		url += "&after=" + after
+	}
+
+	method := "GET"
+	var reqBody io.Reader = nil
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+
 	resBody, err := request.Process[FileObjectsResponse](req)
 
 	if err != nil {
@@ -112,14 +136,80 @@ func DeleteFile(key string, fileID string, orgID string) (*FileDeleteResponse, e
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+key)
-	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, key, orgID, "application/json")
+
+	resBody, err := request.Process[FileDeleteResponse](req)
 
-	if orgID != "" {
-		req.Header.Set("Openai-Organization", orgID)
+	if err != nil {
+		return nil, err
 	}
 
-	resBody, err := request.Process[FileDeleteResponse](req)
+	return resBody, nil
+}
+
+func GetFileContent(key string, fileID string, orgID string) ([]byte, error) {
+	url := fmt.Sprintf("https://api.openai.com/v1/files/%v/content", fileID)
+
+	method := "GET"
+	var reqBody io.Reader = nil
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "")
+
+	resBody, err := request.ProcessRaw(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
+
+func UploadFile(key string, fileName string, content []byte, purpose string, orgID string) (*FileObject, error) {
+	url := "https://api.openai.com/v1/files"
+
+	method := "POST"
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	err := writer.WriteField("purpose", purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = part.Write(content)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, url, &body)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, writer.FormDataContentType())
+
+	resBody, err := request.Process[FileObject](req)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,141 @@
+package openai
+
+import (
+	"fmt"
+
+	"github.com/jackitaliano/oait/internal/pool"
+)
+
+var fineTuningDoneStatuses = []string{"succeeded", "failed", "cancelled"}
+
+// IsDone reports whether the job has reached a final status.
+func (f *FineTuningJob) IsDone() bool {
+	for _, status := range fineTuningDoneStatuses {
+		if f.Status == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func RetrieveFineTuningJobs(key string, jobIDs []string, orgID string) *[]FineTuningJob {
+	jobs := make([]FineTuningJob, len(jobIDs))
+
+	pool.Run(len(jobIDs), pool.DefaultLimit(), func(i int) {
+		job, err := GetFineTuningJob(key, jobIDs[i], orgID)
+
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		jobs[i] = *job
+	})
+
+	return &jobs
+}
+
+// RetrieveAllFineTuningJobs pages through every fine-tuning job, newest first.
+func RetrieveAllFineTuningJobs(key string, orgID string) ([]FineTuningJob, error) {
+	jobs := []FineTuningJob{}
+	after := ""
+
+	for {
+		jobsResponse, err := GetFineTuningJobs(key, after, orgID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, jobsResponse.Data...)
+
+		if !jobsResponse.HasMore || len(jobsResponse.Data) < 1 {
+			break
+		}
+
+		after = jobsResponse.Data[len(jobsResponse.Data)-1].ID
+	}
+
+	return jobs, nil
+}
+
+// RetrieveFineTuningEventsSince returns the job's events newer than sinceID (all
+// events if empty), oldest first.
+func RetrieveFineTuningEventsSince(key string, jobID string, sinceID string, orgID string) ([]FineTuningEvent, error) {
+	events := []FineTuningEvent{}
+	after := ""
+
+	for { // Pages come newest first
+		eventsResponse, err := GetFineTuningEvents(key, jobID, after, orgID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range eventsResponse.Data {
+			if event.ID == sinceID {
+				return reverse(events), nil
+			}
+
+			events = append(events, event)
+		}
+
+		if !eventsResponse.HasMore || len(eventsResponse.Data) < 1 {
+			break
+		}
+
+		after = eventsResponse.Data[len(eventsResponse.Data)-1].ID
+	}
+
+	return reverse(events), nil
+}
+
+// RetrieveAllFineTuningCheckpoints pages through every checkpoint of a job.
+func RetrieveAllFineTuningCheckpoints(key string, jobID string, orgID string) ([]FineTuningCheckpoint, error) {
+	checkpoints := []FineTuningCheckpoint{}
+	after := ""
+
+	for {
+		checkpointsResponse, err := GetFineTuningCheckpoints(key, jobID, after, orgID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		checkpoints = append(checkpoints, checkpointsResponse.Data...)
+
+		if !checkpointsResponse.HasMore || checkpointsResponse.LastID == "" {
+			break
+		}
+
+		after = checkpointsResponse.LastID
+	}
+
+	return checkpoints, nil
+}
+
+func CancelFineTuningJobs(key string, jobIDs []string, orgID string) int {
+	cancelled := make([]bool, len(jobIDs))
+
+	pool.Run(len(jobIDs), pool.DefaultLimit(), func(i int) {
+		_, err := CancelFineTuningJob(key, jobIDs[i], orgID)
+
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		cancelled[i] = true
+	})
+
+	return countTrue(cancelled)
+}
+
+func reverse[T any](list []T) []T {
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+
+	return list
+}
@@ -0,0 +1,220 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/jackitaliano/oait/internal/request"
+)
+
+type FineTuningJob struct {
+	ID              string            `json:"id"`
+	Object          string            `json:"object"`
+	CreatedAt       int64             `json:"created_at"`
+	FinishedAt      int64             `json:"finished_at,omitempty"`
+	Model           string            `json:"model"`
+	FineTunedModel  string            `json:"fine_tuned_model,omitempty"`
+	OrganizationID  string            `json:"organization_id"`
+	Status          string            `json:"status"`
+	Hyperparameters Hyperparameters   `json:"hyperparameters"`
+	TrainingFile    string            `json:"training_file"`
+	ValidationFile  string            `json:"validation_file,omitempty"`
+	ResultFiles     []string          `json:"result_files"`
+	TrainedTokens   int               `json:"trained_tokens,omitempty"`
+	Error           *FineTuningError  `json:"error,omitempty"`
+	Seed            int               `json:"seed"`
+	EstimatedFinish int64             `json:"estimated_finish,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+}
+
+// Hyperparameters are each "auto" or a number.
+type Hyperparameters struct {
+	NEpochs                any `json:"n_epochs,omitempty"`
+	BatchSize              any `json:"batch_size,omitempty"`
+	LearningRateMultiplier any `json:"learning_rate_multiplier,omitempty"`
+}
+
+type FineTuningError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Param   string `json:"param,omitempty"`
+}
+
+type CreatedFineTuningJob struct {
+	Model           string            `json:"model"`
+	TrainingFile    string            `json:"training_file"`
+	ValidationFile  string            `json:"validation_file,omitempty"`
+	Hyperparameters *Hyperparameters  `json:"hyperparameters,omitempty"`
+	Suffix          string            `json:"suffix,omitempty"`
+	Seed            *int              `json:"seed,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+}
+
+type FineTuningJobsResponse struct {
+	Object  string          `json:"object"`
+	Data    []FineTuningJob `json:"data"`
+	HasMore bool            `json:"has_more"`
+}
+
+type FineTuningEvent struct {
+	ID        string `json:"id"`
+	Object    string `json:"object"`
+	CreatedAt int64  `json:"created_at"`
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+	Type      string `json:"type,omitempty"`
+}
+
+type FineTuningEventsResponse struct {
+	Object  string            `json:"object"`
+	Data    []FineTuningEvent `json:"data"`
+	HasMore bool              `json:"has_more"`
+}
+
+type FineTuningCheckpoint struct {
+	ID                       string             `json:"id"`
+	Object                   string             `json:"object"`
+	CreatedAt                int64              `json:"created_at"`
+	FineTunedModelCheckpoint string             `json:"fine_tuned_model_checkpoint"`
+	StepNumber               int                `json:"step_number"`
+	Metrics                  map[string]float64 `json:"metrics"`
+	FineTuningJobID          string             `json:"fine_tuning_job_id"`
+}
+
+type FineTuningCheckpointsResponse struct {
+	Object  string                 `json:"object"`
+	Data    []FineTuningCheckpoint `json:"data"`
+	FirstID string                 `json:"first_id"`
+	LastID  string                 `json:"last_id"`
+	HasMore bool                   `json:"has_more"`
+}
+
+func (f FineTuningJob) GetCreatedAt() int64 {
+	return f.CreatedAt
+}
+
+func (f FineTuningJob) GetName() string {
+	return f.FineTunedModel
+}
+
+func (f FineTuningJob) GetMetadata() map[string]string {
+	return f.Metadata
+}
+
+func GetFineTuningJob(key string, jobID string, orgID string) (*FineTuningJob, error) {
+	url := fmt.Sprintf("https://api.openai.com/v1/fine_tuning/jobs/%v", jobID)
+
+	return fineTuningGet[FineTuningJob](key, url, orgID)
+}
+
+func GetFineTuningJobs(key string, after string, orgID string) (*FineTuningJobsResponse, error) {
+	url := "https://api.openai.com/v1/fine_tuning/jobs?limit=100"
+
+	if after != "" {
+		url += "&after=" + after
+	}
+
+	return fineTuningGet[FineTuningJobsResponse](key, url, orgID)
+}
+
+func GetFineTuningEvents(key string, jobID string, after string, orgID string) (*FineTuningEventsResponse, error) {
+	url := fmt.Sprintf("https://api.openai.com/v1/fine_tuning/jobs/%v/events?limit=100", jobID)
+
+	if after != "" {
+		url += "&after=" + after
+	}
+
+	return fineTuningGet[FineTuningEventsResponse](key, url, orgID)
+}
+
+func GetFineTuningCheckpoints(key string, jobID string, after string, orgID string) (*FineTuningCheckpointsResponse, error) {
+	url := fmt.Sprintf("https://api.openai.com/v1/fine_tuning/jobs/%v/checkpoints?limit=100", jobID)
+
+	if after != "" {
+		url += "&after=" + after
+	}
+
+	return fineTuningGet[FineTuningCheckpointsResponse](key, url, orgID)
+}
+
+func NewFineTuningJob(key string, job *CreatedFineTuningJob, orgID string) (*FineTuningJob, error) {
+	url := "https://api.openai.com/v1/fine_tuning/jobs"
+
+	method := "POST"
+	jsonData, err := json.Marshal(*job)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBody := bytes.NewReader(jsonData)
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+
+	resBody, err := request.Process[FineTuningJob](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
+
+func CancelFineTuningJob(key string, jobID string, orgID string) (*FineTuningJob, error) {
+	url := fmt.Sprintf("https://api.openai.com/v1/fine_tuning/jobs/%v/cancel", jobID)
+
+	method := "POST"
+	var reqBody io.Reader = nil
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+
+	resBody, err := request.Process[FineTuningJob](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
+
+func fineTuningGet[T any](key string, url string, orgID string) (*T, error) {
+	method := "GET"
+	var reqBody io.Reader = nil
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+
+	resBody, err := request.Process[T](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
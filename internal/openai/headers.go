@@ -0,0 +1,35 @@
+package openai
+
+import (
+	"net/http"
+)
+
+var projectID string
+
+// SetProject scopes every request to an OpenAI project, with the OpenAI-Project header.
+func SetProject(project string) {
+	projectID = project
+}
+
+// Project is the project requests are scoped to, if any.
+func Project() string {
+	return projectID
+}
+
+// setHeaders sets the headers common to every request: the key, content type (if
+// any), and the org and project the request is for.
+func setHeaders(req *http.Request, key string, orgID string, contentType string) {
+	req.Header.Set("Authorization", "Bearer "+key)
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if orgID != "" {
+		req.Header.Set("Openai-Organization", orgID)
+	}
+
+	if projectID != "" {
+		req.Header.Set("OpenAI-Project", projectID)
+	}
+}
@@ -0,0 +1,134 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/jackitaliano/oait/internal/request"
+)
+
+type ImageRequest struct {
+	Prompt         string `json:"prompt,omitempty"`
+	Model          string `json:"model,omitempty"`
+	N              int    `json:"n,omitempty"`
+	Size           string `json:"size,omitempty"`
+	Quality        string `json:"quality,omitempty"`
+	Style          string `json:"style,omitempty"`
+	ResponseFormat string `json:"response_format,omitempty"`
+}
+
+type ImagesResponse struct {
+	Created int64   `json:"created"`
+	Data    []Image `json:"data"`
+}
+
+type Image struct {
+	URL           string `json:"url,omitempty"`
+	B64JSON       string `json:"b64_json,omitempty"`
+	RevisedPrompt string `json:"revised_prompt,omitempty"`
+}
+
+func NewImages(key string, imageReq *ImageRequest, orgID string) (*ImagesResponse, error) {
+	url := "https://api.openai.com/v1/images/generations"
+
+	method := "POST"
+	jsonData, err := json.Marshal(*imageReq)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBody := bytes.NewReader(jsonData)
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+
+	resBody, err := request.Process[ImagesResponse](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
+
+// EditImage sends image (and mask, if not nil) with the prompt to edit it.
+func EditImage(key string, image FileUpload, mask *FileUpload, imageReq *ImageRequest, orgID string) (*ImagesResponse, error) {
+	url := "https://api.openai.com/v1/images/edits"
+
+	files := []multipartFile{{"image", image}}
+	if mask != nil {
+		files = append(files, multipartFile{"mask", *mask})
+	}
+
+	return postImageForm(key, url, files, imageReq, orgID)
+}
+
+func NewImageVariations(key string, image FileUpload, imageReq *ImageRequest, orgID string) (*ImagesResponse, error) {
+	url := "https://api.openai.com/v1/images/variations"
+
+	files := []multipartFile{{"image", image}}
+
+	return postImageForm(key, url, files, imageReq, orgID)
+}
+
+// GetImageContent downloads a generated image from its (pre-signed) URL.
+func GetImageContent(url string) ([]byte, error) {
+	method := "GET"
+	var reqBody io.Reader = nil
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	resBody, err := request.ProcessRaw(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
+
+func postImageForm(key string, url string, files []multipartFile, imageReq *ImageRequest, orgID string) (*ImagesResponse, error) {
+	fields := [][2]string{
+		{"prompt", imageReq.Prompt},
+		{"model", imageReq.Model},
+		{"size", imageReq.Size},
+		{"quality", imageReq.Quality},
+		{"response_format", imageReq.ResponseFormat},
+	}
+
+	if imageReq.N > 0 {
+		fields = append(fields, [2]string{"n", strconv.Itoa(imageReq.N)})
+	}
+
+	req, err := newMultipartRequest(key, url, files, fields, orgID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := request.Process[ImagesResponse](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
@@ -0,0 +1,156 @@
+package openai
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jackitaliano/oait/internal/request"
+)
+
+// imageForm is what the stand-in received of a multipart image request.
+type imageForm struct {
+	path   string
+	auth   string
+	org    string
+	files  map[string]FileUpload
+	fields map[string]string
+}
+
+// newImagesServer stands in for the API, recording the form posted and answering
+// with one b64 image.
+func newImagesServer(t *testing.T, form *imageForm) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		form.path = r.URL.Path
+		form.auth = r.Header.Get("Authorization")
+		form.org = r.Header.Get("Openai-Organization")
+		form.files = make(map[string]FileUpload)
+		form.fields = make(map[string]string)
+
+		err := r.ParseMultipartForm(1 << 20)
+
+		if err != nil {
+			t.Errorf("parsing multipart form: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		for field, headers := range r.MultipartForm.File {
+			f, err := headers[0].Open()
+
+			if err != nil {
+				t.Fatalf("opening %v: %v", field, err)
+			}
+
+			content, _ := io.ReadAll(f)
+			f.Close()
+
+			form.files[field] = FileUpload{headers[0].Filename, content}
+		}
+
+		for field, values := range r.MultipartForm.Value {
+			form.fields[field] = values[0]
+		}
+
+		json.NewEncoder(w).Encode(ImagesResponse{Created: 1, Data: []Image{{B64JSON: "aW1n"}}})
+	}))
+
+	t.Cleanup(srv.Close)
+
+	if err := request.SetBaseURL(srv.URL + "/v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { request.SetBaseURL("") })
+}
+
+func TestEditImage(t *testing.T) {
+	var form imageForm
+	newImagesServer(t, &form)
+
+	image := FileUpload{"cat.png", []byte("image bytes")}
+	mask := FileUpload{"mask.png", []byte("mask bytes")}
+	imageReq := ImageRequest{Prompt: "add a hat", N: 2, ResponseFormat: "b64_json"}
+
+	res, err := EditImage("sk-test", image, &mask, &imageReq, "org-test")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if form.path != "/v1/images/edits" {
+		t.Errorf("path = %q, want /v1/images/edits", form.path)
+	}
+
+	if form.auth != "Bearer sk-test" || form.org != "org-test" {
+		t.Errorf("headers = %q, %q, want key and org", form.auth, form.org)
+	}
+
+	for field, want := range map[string]FileUpload{"image": image, "mask": mask} {
+		got := form.files[field]
+
+		if got.Name != want.Name || string(got.Content) != string(want.Content) {
+			t.Errorf("%v = %q (%q), want %q (%q)", field, got.Name, got.Content, want.Name, want.Content)
+		}
+	}
+
+	want := map[string]string{"prompt": "add a hat", "n": "2", "response_format": "b64_json"}
+
+	if len(form.fields) != len(want) {
+		t.Errorf("fields = %v, want only %v", form.fields, want)
+	}
+
+	for field, value := range want {
+		if form.fields[field] != value {
+			t.Errorf("%v = %q, want %q", field, form.fields[field], value)
+		}
+	}
+
+	if len(res.Data) != 1 || res.Data[0].B64JSON != "aW1n" {
+		t.Errorf("response = %+v", res)
+	}
+}
+
+func TestNewImageVariations(t *testing.T) {
+	var form imageForm
+	newImagesServer(t, &form)
+
+	image := FileUpload{"cat.png", []byte("image bytes")}
+	imageReq := ImageRequest{Model: "dall-e-2", Size: "256x256"}
+
+	_, err := NewImageVariations("sk-test", image, &imageReq, "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if form.path != "/v1/images/variations" {
+		t.Errorf("path = %q, want /v1/images/variations", form.path)
+	}
+
+	if _, ok := form.files["mask"]; ok || len(form.files) != 1 {
+		t.Errorf("files = %v, want only image", form.files)
+	}
+
+	if got := form.files["image"]; got.Name != "cat.png" || string(got.Content) != "image bytes" {
+		t.Errorf("image = %q (%q)", got.Name, got.Content)
+	}
+
+	if _, ok := form.fields["prompt"]; ok {
+		t.Errorf("prompt sent, though empty")
+	}
+
+	if _, ok := form.fields["n"]; ok {
+		t.Errorf("n sent, though 0")
+	}
+
+	if form.fields["model"] != "dall-e-2" || form.fields["size"] != "256x256" {
+		t.Errorf("fields = %v", form.fields)
+	}
+
+	if form.org != "" {
+		t.Errorf("org header = %q, want none", form.org)
+	}
+}
@@ -0,0 +1,55 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/jackitaliano/oait/internal/request"
+)
+
+type Model struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+}
+
+type ModelsResponse struct {
+	Object string  `json:"object"`
+	Data   []Model `json:"data"`
+}
+
+func (m Model) GetCreatedAt() int64 {
+	return m.Created
+}
+
+func (m Model) GetName() string {
+	return m.ID
+}
+
+func GetModels(key string, orgID string) (*ModelsResponse, error) {
+	url := "https://api.openai.com/v1/models"
+
+	method := "GET"
+	var reqBody io.Reader = nil
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+
+	resBody, err := request.Process[ModelsResponse](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
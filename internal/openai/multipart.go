@@ -0,0 +1,66 @@
+package openai
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+)
+
+type FileUpload struct {
+	Name    string
+	Content []byte
+}
+
+type multipartFile struct {
+	field string
+	file  FileUpload
+}
+
+// newMultipartRequest builds a POST of files and the non-empty fields as multipart/form-data.
+func newMultipartRequest(key string, url string, files []multipartFile, fields [][2]string, orgID string) (*http.Request, error) {
+	method := "POST"
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	for _, f := range files {
+		part, err := writer.CreateFormFile(f.field, f.file.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = part.Write(f.file.Content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, field := range fields {
+		if field[1] == "" {
+			continue
+		}
+
+		err := writer.WriteField(field[0], field[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, url, &body)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, writer.FormDataContentType())
+
+	return req, nil
+}
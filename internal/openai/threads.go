@@ -2,6 +2,8 @@ package openai
 
 import (
 	"fmt"
+
+	"github.com/jackitaliano/oait/internal/pool"
 )
 
 func AddMessage(key string, threadID string, createdMessage *CreatedMessage, orgID string) (*Message, error) {
@@ -14,108 +16,110 @@ func AddMessage(key string, threadID string, createdMessage *CreatedMessage, org
 	return message, nil
 }
 
-func deleteThread(c chan *ThreadDeleteResponse, key string, threadID string, orgID string) {
+func CreateThread(key string, createdThread *CreatedThread, orgID string) (*Thread, error) {
+	thread, err := NewThread(key, createdThread, orgID)
 
-	deleteResponse, err := DeleteThread(key, threadID, orgID)
+	return thread, err
+}
 
-	if err != nil {
-		fmt.Println(err)
-		c <- nil
-		return
+func addThreadMessages(key string, threadID string, createdMessages []CreatedMessage, orgID string) int {
+	numAdded := 0
+
+	for _, createdMessage := range createdMessages {
+		_, err := PostMessage(key, threadID, &createdMessage, orgID)
+
+		if err != nil {
+			fmt.Println(err)
+			break
+		}
+
+		numAdded += 1
 	}
 
-	c <- deleteResponse
+	return numAdded
 }
 
-func DeleteThreads(key string, threadIDs []string, orgID string) int {
-	c := make(chan *ThreadDeleteResponse, len(threadIDs))
+func AddMessages(key string, threadIDs []string, createdMessages []CreatedMessage, orgID string) int {
+	added := make([]int, len(threadIDs))
 
-	for _, threadID := range threadIDs {
-		go deleteThread(c, key, threadID, orgID)
+	pool.Run(len(threadIDs), pool.DefaultLimit(), func(i int) {
+		added[i] = addThreadMessages(key, threadIDs[i], createdMessages, orgID)
+	})
+
+	numAdded := 0
+	for _, n := range added {
+		numAdded += n
 	}
 
-	results := make([]*ThreadDeleteResponse, len(threadIDs))
-	numDeleted := 0
+	return numAdded
+}
+
+func DeleteThreads(key string, threadIDs []string, orgID string) int {
+	deleted := make([]bool, len(threadIDs))
 
-	for i := range results {
-		res := <-c
-		results[i] = res
+	pool.Run(len(threadIDs), pool.DefaultLimit(), func(i int) {
+		deleteResponse, err := DeleteThread(key, threadIDs[i], orgID)
 
-		if res.Deleted {
-			numDeleted += 1
+		if err != nil {
+			fmt.Println(err)
+			return
 		}
-	}
 
-	return numDeleted
+		deleted[i] = deleteResponse.Deleted
+	})
+
+	return countTrue(deleted)
 }
 
-func retrieveThreadMessages(c chan *Messages, key string, threadID string, orgID string) {
+func retrieveThreadMessages(key string, threadID string, orgID string) Messages {
 
 	messageResponse, err := GetThreadMessages(key, threadID, orgID)
 
 	if err != nil {
 		fmt.Println(err)
-		c <- &Messages{}
-		return
+		return Messages{}
 	}
 
-	messageData := &Messages{Messages: (*messageResponse).Data}
-	c <- messageData
+	return Messages{Messages: (*messageResponse).Data}
 }
 
+// RetrieveThreadsMessages gets each thread's messages, in the order of threadIDs.
 func RetrieveThreadsMessages(key string, threadIDs []string, orgID string) *[]Messages {
-	c := make(chan *Messages, len(threadIDs))
-
-	for _, threadID := range threadIDs {
-		go retrieveThreadMessages(c, key, threadID, orgID)
-	}
+	threads := make([]Messages, len(threadIDs))
 
-	results := make([]*Messages, len(threadIDs))
-	for i := range results {
-		results[i] = <-c
-	}
-
-	threads := []Messages{}
-	for _, thread := range results {
-		if thread != nil {
-			threads = append(threads, *thread)
-		}
-	}
+	pool.Run(len(threadIDs), pool.DefaultLimit(), func(i int) {
+		threads[i] = retrieveThreadMessages(key, threadIDs[i], orgID)
+	})
 
 	return &threads
 }
 
-func retrieveThread(c chan *Thread, key string, threadID string, orgID string) {
-
-	thread, err := GetThread(key, threadID, orgID)
+func RetrieveThreads(key string, threadIDs []string, orgID string) *[]Thread {
+	threads := make([]Thread, len(threadIDs))
 
-	if err != nil {
-		fmt.Println(err)
-		c <- &Thread{}
-		return
-	}
+	pool.Run(len(threadIDs), pool.DefaultLimit(), func(i int) {
+		thread, err := GetThread(key, threadIDs[i], orgID)
 
-	c <- thread
-}
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
 
-func RetrieveThreads(key string, threadIDs []string, orgID string) *[]Thread {
-	c := make(chan *Thread, len(threadIDs))
+		threads[i] = *thread
+	})
 
-	for _, threadID := range threadIDs {
-		go retrieveThread(c, key, threadID, orgID)
-	}
+	return &threads
+}
 
-	results := make([]*Thread, len(threadIDs))
-	for i := range results {
-		results[i] = <-c
-	}
+// countTrue counts the requests that succeeded, e.g. deletions.
+func countTrue(results []bool) int {
+	count := 0
 
-	threads := []Thread{}
-	for _, thread := range results {
-		if thread != nil {
-			threads = append(threads, *thread)
+	for _, ok := range results {
+		if ok {
+			count += 1
 		}
 	}
 
-	return &threads
+	return count
 }
@@ -7,13 +7,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/jackitaliano/oait/internal/request"
 )
 
 type MessagesResponse struct {
-	Object string    `json:"object"`
-	Data   []Message `json:"data"`
+	Object  string    `json:"object"`
+	Data    []Message `json:"data"`
+	LastID  string    `json:"last_id,omitempty"`
+	HasMore bool      `json:"has_more,omitempty"`
 }
 
 type SessionThreadsResponse struct {
@@ -49,8 +52,9 @@ type Message struct {
 	ThreadID    string           `json:"thread_id"`
 	RunID       string           `json:"run_id,omitempty"`
 	Role        string           `json:"role"`
-	Content     []MessageContent `json:"content"`
-	Attachments []Attachment     `json:"attachments,omitempty"`
+	Content     []MessageContent  `json:"content"`
+	Attachments []Attachment      `json:"attachments,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
 }
 
 type Attachment struct {
@@ -74,8 +78,14 @@ type ImageFile struct {
 }
 
 type CreatedMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role        string            `json:"role"`
+	Content     string            `json:"content"`
+	Attachments []Attachment      `json:"attachments,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+type CreatedThread struct {
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 type Annotation struct {
@@ -122,6 +132,29 @@ func (t Thread) GetMetadata() map[string]string {
 	return t.Metadata;
 }
 
+func (m Message) GetText() string {
+	texts := []string{}
+
+	for _, c := range m.Content {
+		if c.Type == "text" && c.Text != nil {
+			texts = append(texts, c.Text.Value)
+		}
+	}
+
+	return strings.Join(texts, "\n")
+}
+
+func (m Message) ToCreated() *CreatedMessage {
+	createdMessage := CreatedMessage{
+		Role:        m.Role,
+		Content:     m.GetText(),
+		Attachments: m.Attachments,
+		Metadata:    m.Metadata,
+	}
+
+	return &createdMessage
+}
+
 func GetThreadMessages(key string, threadID string, orgID string) (*MessagesResponse, error) {
 	url := fmt.Sprintf("https://api.openai.com/v1/threads/%v/messages?limit=100", threadID)
 	method := "GET"
@@ -135,14 +168,40 @@ func GetThreadMessages(key string, threadID string, orgID string) (*MessagesResp
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+key)
-	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, key, orgID, "application/json")
 	req.Header.Set("OpenAI-Beta", "assistants=v2")
 
-	if orgID != "" {
-		req.Header.Set("Openai-Organization", orgID)
+	resBody, err := request.Process[MessagesResponse](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
+
+// GetThreadMessagesPage gets a page of a thread's messages, oldest first, after the given ID (if any).
+func GetThreadMessagesPage(key string, threadID string, after string, orgID string) (*MessagesResponse, error) {
+	url := fmt.Sprintf("https://api.openai.com/v1/threads/%v/messages?limit=100&order=asc", threadID)
+
+	if after != "" {
+		url += "&after=" + after
 	}
 
+	method := "GET"
+	var reqBody io.Reader = nil
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+	req.Header.Set("OpenAI-Beta", "assistants=v2")
+
 	resBody, err := request.Process[MessagesResponse](req)
 
 	if err != nil {
@@ -165,14 +224,9 @@ func GetSessionThreads(sessionID string, orgID string) (*SessionThreadsResponse,
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+sessionID)
-	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, sessionID, orgID, "application/json")
 	req.Header.Set("Openai-Beta", "assistants=v1")
 
-	if orgID != "" {
-		req.Header.Set("Openai-Organization", orgID)
-	}
-
 	resBody, err := request.Process[SessionThreadsResponse](req)
 
 	if err != nil {
@@ -195,14 +249,9 @@ func GetThread(key string, threadId string, orgID string) (*Thread, error) {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+key)
-	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, key, orgID, "application/json")
 	req.Header.Set("Openai-Beta", "assistants=v1")
 
-	if orgID != "" {
-		req.Header.Set("Openai-Organization", orgID)
-	}
-
 	resBody, err := request.Process[Thread](req)
 
 	if err != nil {
@@ -225,14 +274,9 @@ func DeleteThread(key string, threadID string, orgID string) (*ThreadDeleteRespo
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+key)
-	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, key, orgID, "application/json")
 	req.Header.Set("OpenAI-Beta", "assistants=v2")
 
-	if orgID != "" {
-		req.Header.Set("Openai-Organization", orgID)
-	}
-
 	resBody, err := request.Process[ThreadDeleteResponse](req)
 
 	if err != nil {
@@ -261,15 +305,41 @@ func PostMessage(key string, threadID string, message *CreatedMessage, orgID str
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+key)
-	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, key, orgID, "application/json")
 	req.Header.Set("OpenAI-Beta", "assistants=v2")
 
-	if orgID != "" {
-		req.Header.Set("Openai-Organization", orgID)
+	resBody, err := request.Process[Message](req)
+
+	if err != nil {
+		return nil, err
 	}
 
-	resBody, err := request.Process[Message](req)
+	return resBody, nil
+}
+
+func NewThread(key string, thread *CreatedThread, orgID string) (*Thread, error) {
+	url := "https://api.openai.com/v1/threads"
+	method := "POST"
+
+	jsonData, err := json.Marshal(thread)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBody := bytes.NewReader(jsonData)
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+	req.Header.Set("OpenAI-Beta", "assistants=v2")
+
+	resBody, err := request.Process[Thread](req)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,27 @@
+package openai
+
+// RetrieveAllVectorStoreFileIDs pages through every file in a vector store.
+func RetrieveAllVectorStoreFileIDs(key string, vectorStoreID string, orgID string) ([]string, error) {
+	fileIDs := []string{}
+	after := ""
+
+	for {
+		filesResponse, err := GetVectorStoreFiles(key, vectorStoreID, after, orgID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, vectorStoreFile := range filesResponse.Data {
+			fileIDs = append(fileIDs, vectorStoreFile.ID)
+		}
+
+		if !filesResponse.HasMore || filesResponse.LastID == "" {
+			break
+		}
+
+		after = filesResponse.LastID
+	}
+
+	return fileIDs, nil
+}
@@ -0,0 +1,138 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/jackitaliano/oait/internal/request"
+)
+
+type VectorStore struct {
+	ID         string            `json:"id"`
+	Object     string            `json:"object"`
+	CreatedAt  int64             `json:"created_at"`
+	Name       string            `json:"name"`
+	Status     string            `json:"status"`
+	FileCounts FileCounts        `json:"file_counts"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+}
+
+type FileCounts struct {
+	InProgress int `json:"in_progress"`
+	Completed  int `json:"completed"`
+	Failed     int `json:"failed"`
+	Cancelled  int `json:"cancelled"`
+	Total      int `json:"total"`
+}
+
+type CreatedVectorStore struct {
+	Name     string            `json:"name,omitempty"`
+	FileIDs  []string          `json:"file_ids,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type VectorStoreFile struct {
+	ID            string `json:"id"`
+	Object        string `json:"object"`
+	CreatedAt     int64  `json:"created_at"`
+	VectorStoreID string `json:"vector_store_id"`
+	Status        string `json:"status"`
+}
+
+type VectorStoreFilesResponse struct {
+	Data    []VectorStoreFile `json:"data"`
+	Object  string            `json:"object"`
+	LastID  string            `json:"last_id"`
+	HasMore bool              `json:"has_more"`
+}
+
+func GetVectorStore(key string, vectorStoreID string, orgID string) (*VectorStore, error) {
+	url := fmt.Sprintf("https://api.openai.com/v1/vector_stores/%v", vectorStoreID)
+
+	method := "GET"
+	var reqBody io.Reader = nil
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+	req.Header.Set("Openai-Beta", "assistants=v2")
+
+	resBody, err := request.Process[VectorStore](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
+
+func GetVectorStoreFiles(key string, vectorStoreID string, after string, orgID string) (*VectorStoreFilesResponse, error) {
+	url := fmt.Sprintf("https://api.openai.com/v1/vector_stores/%v/files?limit=100", vectorStoreID)
+
+	if after != "" {
+		url += "&after=" + after
+	}
+
+	method := "GET"
+	var reqBody io.Reader = nil
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+	req.Header.Set("Openai-Beta", "assistants=v2")
+
+	resBody, err := request.Process[VectorStoreFilesResponse](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
+
+func NewVectorStore(key string, vectorStore *CreatedVectorStore, orgID string) (*VectorStore, error) {
+	url := "https://api.openai.com/v1/vector_stores"
+
+	method := "POST"
+	jsonData, err := json.Marshal(*vectorStore)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBody := bytes.NewReader(jsonData)
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+	req.Header.Set("Openai-Beta", "assistants=v2")
+
+	resBody, err := request.Process[VectorStore](req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resBody, nil
+}
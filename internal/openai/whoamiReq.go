@@ -0,0 +1,75 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/request"
+)
+
+const (
+	UserKey           = "user"
+	ServiceAccountKey = "service-account"
+	AdminKey          = "admin"
+)
+
+type Identity struct {
+	Type    string `json:"type"`
+	Org     string `json:"org"`
+	Project string `json:"project"`
+}
+
+// KeyType tells user, service account and admin keys apart by their prefix. Project
+// keys (sk-proj-) belong to users; service accounts' have their own prefix.
+func KeyType(key string) string {
+	if strings.HasPrefix(key, "sk-admin-") {
+		return AdminKey
+
+	} else if strings.HasPrefix(key, "sk-svcacct-") {
+		return ServiceAccountKey
+	}
+
+	return UserKey
+}
+
+// GetIdentity makes the cheapest request the key is allowed to, and reads the org
+// and project it resolved to from the response headers. Admin keys only work with
+// the organization endpoints and are org-wide, so have no project.
+func GetIdentity(key string, orgID string) (*Identity, error) {
+	keyType := KeyType(key)
+	url := "https://api.openai.com/v1/models"
+
+	if keyType == AdminKey {
+		url = "https://api.openai.com/v1/organization/projects?limit=1"
+	}
+
+	method := "GET"
+	var reqBody io.Reader = nil
+
+	req, err := http.NewRequest(method, url, reqBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error creating request to '%v':\nError: %v", url, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	setHeaders(req, key, orgID, "application/json")
+
+	header, err := request.ProcessHeader(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	identity := Identity{
+		Type:    keyType,
+		Org:     header.Get("Openai-Organization"),
+		Project: header.Get("Openai-Project"),
+	}
+
+	return &identity, nil
+}
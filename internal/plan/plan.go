@@ -0,0 +1,136 @@
+package plan
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackitaliano/oait/internal/openai"
+)
+
+type Plan struct {
+	Resource  string `json:"resource"`
+	Action    string `json:"action"`
+	OrgID     string `json:"org_id,omitempty"`
+	CreatedAt string `json:"created_at"`
+	Items     []Item `json:"items"`
+}
+
+type Item struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name,omitempty"`
+	CreatedAt string   `json:"created_at,omitempty"`
+	Bytes     int      `json:"bytes,omitempty"`
+	Messages  int      `json:"messages,omitempty"`
+	Reasons   []string `json:"reasons"`
+}
+
+func NewDeletePlan(resource string, orgID string, items []Item) *Plan {
+	return &Plan{
+		Resource:  resource,
+		Action:    "delete",
+		OrgID:     orgID,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Items:     items,
+	}
+}
+
+func ThreadItems(threads *[]openai.Messages, reasons []string) []Item {
+	items := []Item{}
+
+	for _, thread := range *threads {
+		if thread.GetLen() < 1 {
+			continue
+		}
+
+		item := Item{
+			ID:        thread.Messages[0].ThreadID,
+			CreatedAt: formatUnix(thread.GetCreatedAt()),
+			Messages:  thread.GetLen(),
+			Reasons:   reasons,
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func FileItems(fileObjects *[]openai.FileObject, reasons []string) []Item {
+	items := make([]Item, len(*fileObjects))
+
+	for i, fileObject := range *fileObjects {
+		items[i] = Item{
+			ID:        fileObject.ID,
+			Name:      fileObject.Filename,
+			CreatedAt: formatUnix(fileObject.Created),
+			Bytes:     fileObject.Bytes,
+			Reasons:   reasons,
+		}
+	}
+
+	return items
+}
+
+func AsstItems(asstObjects *[]openai.AsstObject, reasons []string) []Item {
+	items := make([]Item, len(*asstObjects))
+
+	for i, asstObject := range *asstObjects {
+		items[i] = Item{
+			ID:        asstObject.ID,
+			Name:      asstObject.Name,
+			CreatedAt: formatUnix(asstObject.CreatedAt),
+			Reasons:   reasons,
+		}
+	}
+
+	return items
+}
+
+// GetIDs returns the planned IDs, refusing plans made for another resource or action.
+func (p Plan) GetIDs(resource string) ([]string, error) {
+	if p.Resource != resource || p.Action != "delete" {
+		errMsg := fmt.Sprintf("Invalid plan: '%v %v' (expected '%v delete')", p.Resource, p.Action, resource)
+		err := errors.New(errMsg)
+		return nil, err
+	}
+
+	ids := make([]string, len(p.Items))
+
+	for i, item := range p.Items {
+		if item.ID == "" {
+			errMsg := fmt.Sprintf("Invalid plan: item %v has no id", i+1)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+
+		ids[i] = item.ID
+	}
+
+	return ids, nil
+}
+
+func formatUnix(unixTime int64) string {
+	if unixTime == 0 {
+		return ""
+	}
+
+	return time.Unix(unixTime, 0).UTC().Format(time.RFC3339)
+}
+
+// KeepIDs drops the items whose IDs aren't in ids.
+func KeepIDs(items []Item, ids []string) []Item {
+	keep := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		keep[id] = true
+	}
+
+	kept := []Item{}
+	for _, item := range items {
+		if keep[item.ID] {
+			kept = append(kept, item)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,45 @@
+package pool
+
+import (
+	"sync"
+)
+
+var defaultLimit = 8
+
+// SetDefaultLimit sets how many requests the bulk helpers (deleting, retrieving, adding
+// to many IDs) send at once, e.g. from a profile's concurrency. A limit < 1 is ignored.
+func SetDefaultLimit(limit int) {
+	if limit > 0 {
+		defaultLimit = limit
+	}
+}
+
+// DefaultLimit is how many requests the bulk helpers send at once (8 unless set).
+func DefaultLimit() int {
+	return defaultLimit
+}
+
+// Run calls fn(i) for every i in [0, count), with at most limit calls running at once.
+// A limit < 1 runs them all at once.
+func Run(count int, limit int, fn func(i int)) {
+	if limit < 1 || limit > count {
+		limit = count
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, max(limit, 1))
+
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			fn(i)
+		}(i)
+	}
+
+	wg.Wait()
+}
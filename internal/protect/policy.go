@@ -0,0 +1,135 @@
+package protect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+const PolicyEnv = "OAIT_PROTECT_FILE"
+
+type Policy struct {
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Names    []string          `json:"names,omitempty"`
+	IDs      []string          `json:"ids,omitempty"`
+}
+
+type Target struct {
+	ID        string
+	Name      string
+	Metadata  map[string]string
+	Unchecked bool
+}
+
+// Load reads the policy from OAIT_PROTECT_FILE, or <config dir>/oait/protect.json if it exists.
+func Load() (*Policy, error) {
+	fileName := os.Getenv(PolicyEnv)
+	required := fileName != ""
+
+	if !required {
+		configDir, err := os.UserConfigDir()
+
+		if err != nil {
+			return &Policy{}, nil
+		}
+
+		fileName = filepath.Join(configDir, "oait", "protect.json")
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if errors.Is(err, os.ErrNotExist) && !required {
+		return &Policy{}, nil
+	}
+
+	if err != nil {
+		err = errors.New("Failed reading protection policy: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	var policy Policy
+	err = json.Unmarshal(data, &policy)
+
+	if err != nil {
+		err = errors.New("Failed parsing protection policy: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	for _, pattern := range policy.Names {
+		_, err := path.Match(pattern, "")
+
+		if err != nil {
+			errMsg := fmt.Sprintf("Invalid name pattern '%v' in protection policy: %v", pattern, err)
+			err = errors.New(errMsg)
+			return nil, err
+		}
+	}
+
+	return &policy, nil
+}
+
+func (p Policy) IsEmpty() bool {
+	return len(p.Metadata) == 0 && len(p.Names) == 0 && len(p.IDs) == 0
+}
+
+// Match returns why target is protected, or false if it isn't.
+func (p Policy) Match(target Target) (string, bool) {
+	if target.Unchecked {
+		return "could not be retrieved to check", true
+	}
+
+	for _, id := range p.IDs {
+		if target.ID == id {
+			return "protected id", true
+		}
+	}
+
+	if target.Name != "" {
+		for _, pattern := range p.Names {
+			matched, _ := path.Match(pattern, target.Name)
+
+			if matched {
+				return fmt.Sprintf("name matches '%v'", pattern), true
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(p.Metadata))
+	for key := range p.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		val, ok := target.Metadata[key]
+
+		if ok && val == p.Metadata[key] {
+			return fmt.Sprintf("metadata %v=%v", key, val), true
+		}
+	}
+
+	return "", false
+}
+
+// Split separates the IDs of unprotected targets from descriptions of protected ones.
+func (p Policy) Split(targets []Target) ([]string, []string) {
+	allowed := []string{}
+	protected := []string{}
+
+	for _, target := range targets {
+		reason, ok := p.Match(target)
+
+		if ok {
+			protected = append(protected, fmt.Sprintf("%v (%v)", target.ID, reason))
+			continue
+		}
+
+		allowed = append(allowed, target.ID)
+	}
+
+	return allowed, protected
+}
@@ -0,0 +1,93 @@
+package protect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/openai"
+)
+
+// Filter drops the threads, files or assts (by kind) the policy protects from ids,
+// listing those skipped, unless force.
+func Filter(key string, kind string, ids []string, orgID string, force bool) ([]string, error) {
+	policy, err := Load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if policy.IsEmpty() || force || len(ids) < 1 {
+		return ids, nil
+	}
+
+	fmt.Printf("Checking protected %v...\t", kind)
+	var targets []Target
+
+	switch kind {
+	case "threads":
+		targets = ThreadTargets(key, ids, orgID)
+	case "files":
+		targets = FileTargets(key, ids, orgID)
+	case "assts":
+		targets = AsstTargets(key, ids, orgID)
+	}
+
+	allowed, protected := policy.Split(targets)
+	fmt.Printf("✓\n")
+
+	if len(protected) > 0 {
+		fmt.Printf("Skipping %v protected %v (use --force-protected to include):\n", len(protected), kind)
+		fmt.Printf("\t%v\n", strings.Join(protected, "\n\t"))
+	}
+
+	return allowed, nil
+}
+
+func ThreadTargets(key string, threadIDs []string, orgID string) []Target {
+	threads := openai.RetrieveThreads(key, threadIDs, orgID)
+
+	retrieved := make(map[string]Target, len(*threads))
+	for _, thread := range *threads {
+		retrieved[thread.ID] = Target{ID: thread.ID, Metadata: thread.Metadata}
+	}
+
+	return orderTargets(threadIDs, retrieved)
+}
+
+func FileTargets(key string, fileIDs []string, orgID string) []Target {
+	fileObjects := openai.RetrieveFiles(key, fileIDs, orgID)
+
+	retrieved := make(map[string]Target, len(*fileObjects))
+	for _, fileObject := range *fileObjects {
+		retrieved[fileObject.ID] = Target{ID: fileObject.ID, Name: fileObject.Filename}
+	}
+
+	return orderTargets(fileIDs, retrieved)
+}
+
+func AsstTargets(key string, asstIDs []string, orgID string) []Target {
+	asstObjects := openai.RetrieveAssts(key, asstIDs, orgID)
+
+	retrieved := make(map[string]Target, len(*asstObjects))
+	for _, asstObject := range *asstObjects {
+		retrieved[asstObject.ID] = Target{ID: asstObject.ID, Name: asstObject.Name, Metadata: asstObject.Metadata}
+	}
+
+	return orderTargets(asstIDs, retrieved)
+}
+
+func orderTargets(ids []string, retrieved map[string]Target) []Target {
+	targets := make([]Target, len(ids))
+
+	for i, id := range ids {
+		target, ok := retrieved[id]
+
+		if !ok {
+			target = Target{ID: id, Unchecked: true}
+		}
+
+		targets[i] = target
+	}
+
+	return targets
+}
@@ -1,12 +1,24 @@
 package request
 
 import (
+	"bufio"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
 )
 
+const apiHost = "api.openai.com"
+
+var baseURL *url.URL
+
+// keyRegexp matches OpenAI secret keys, e.g. echoed back in an error message.
+var keyRegexp = regexp.MustCompile(`sk-[A-Za-z0-9_\-*]{8,}`)
+
 type Error struct {
 	Message string `json:"message"`
 	Type    string `json:"type"`
@@ -21,34 +33,163 @@ type ErrorResponse struct {
 type Response interface{}
 
 func Process[T Response](req *http.Request) (*T, error) {
+	res, err := send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var resBody T
+	err = json.NewDecoder(res.Body).Decode(&resBody)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error reading response body from '%v':\n%v\n", req.URL.Redacted(), err)
+		err = errors.New(redact(req, errMsg))
+		return nil, err
+	}
+
+	return &resBody, nil
+}
+
+// ProcessRaw is Process for responses that aren't JSON (e.g. file content).
+func ProcessRaw(req *http.Request) ([]byte, error) {
+	res, err := send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error reading response body from '%v':\n%v\n", req.URL.Redacted(), err)
+		err = errors.New(redact(req, errMsg))
+		return nil, err
+	}
+
+	return resBody, nil
+}
+
+// ProcessHeader is Process for when only the response headers matter.
+func ProcessHeader(req *http.Request) (http.Header, error) {
+	res, err := send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	io.Copy(io.Discard, res.Body)
+
+	return res.Header, nil
+}
+
+// SetBaseURL sends requests for the OpenAI API (https://api.openai.com/v1/...) to
+// another base URL instead, e.g. a proxy or a compatible server.
+func SetBaseURL(base string) error {
+	if base == "" {
+		baseURL = nil
+		return nil
+	}
+
+	parsed, err := url.Parse(strings.TrimSuffix(base, "/"))
+
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		errMsg := fmt.Sprintf("Invalid base URL: '%v'. (should be like 'https://host/v1')", base)
+		err = errors.New(errMsg)
+		return err
+	}
+
+	baseURL = parsed
+
+	return nil
+}
+
+func send(req *http.Request) (*http.Response, error) {
 	client := &http.Client{}
 
+	if baseURL != nil && req.URL.Host == apiHost {
+		rebased := *req.URL
+		rebased.Scheme = baseURL.Scheme
+		rebased.Host = baseURL.Host
+		rebased.Path = baseURL.Path + strings.TrimPrefix(req.URL.Path, "/v1")
+
+		req.URL = &rebased
+		req.Host = rebased.Host
+	}
+
 	res, err := client.Do(req)
 	if err != nil {
-		errMsg := fmt.Sprintf("Error making request to '%v':\nError: %v", *req.URL, err)
-		err = errors.New(errMsg)
+		errMsg := fmt.Sprintf("Error making request to '%v':\nError: %v", req.URL.Redacted(), err)
+		err = errors.New(redact(req, errMsg))
 		return nil, err
 	}
-	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+
 		var errRes ErrorResponse
 		json.NewDecoder(res.Body).Decode(&errRes)
 
-		errMsg := fmt.Sprintf("Error: Request (%v). Status: (%v). Message: %v", *req.URL, res.StatusCode, errRes.Error.Message)
-		err = errors.New(errMsg)
+		errMsg := fmt.Sprintf("Error: Request (%v). Status: (%v). Message: %v", req.URL.Redacted(), res.StatusCode, errRes.Error.Message)
+		err = errors.New(redact(req, errMsg))
 
 		return nil, err
 	}
 
-	var resBody T
-	err = json.NewDecoder(res.Body).Decode(&resBody)
+	return res, nil
+}
 
+// Stream reads a server-sent events response, calling onData with each event's data
+// until the stream ends or sends [DONE].
+func Stream(req *http.Request, onData func(data []byte) error) error {
+	res, err := send(req)
 	if err != nil {
-		errMsg := fmt.Sprintf("Error reading response body from '%v':\n%v\n", *req.URL, err)
-		err = errors.New(errMsg)
-		return nil, err
+		return err
 	}
+	defer res.Body.Close()
 
-	return &resBody, nil
+	scanner := bufio.NewScanner(res.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+
+		if data == "[DONE]" {
+			return nil
+		}
+
+		err = onData([]byte(data))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	err = scanner.Err()
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error reading stream from '%v':\n%v\n", req.URL.Redacted(), err)
+		err = errors.New(redact(req, errMsg))
+		return err
+	}
+
+	return nil
+}
+
+// redact keeps credentials out of error messages, which end up in terminals and logs:
+// the request's bearer token, and anything else that looks like a secret key.
+func redact(req *http.Request, msg string) string {
+	token := strings.TrimSpace(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+
+	if token != "" {
+		msg = strings.ReplaceAll(msg, token, "[REDACTED]")
+	}
+
+	return keyRegexp.ReplaceAllString(msg, "sk-[REDACTED]")
 }
@@ -0,0 +1,216 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+var functionName = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// ValidateFunction checks a function tool definition locally: its name, and that its
+// parameters are valid JSON Schema (against the draft 2020-12 meta-schema) describing an object.
+func ValidateFunction(function *openai.Function) error {
+	if !functionName.MatchString(function.Name) {
+		errMsg := fmt.Sprintf("Invalid function name '%v': must be 1-64 of a-z, A-Z, 0-9, '_' or '-'", function.Name)
+		err := errors.New(errMsg)
+		return err
+	}
+
+	if len(function.Parameters) < 1 {
+		return nil
+	}
+
+	err := validateSchema(function.Name+".parameters.json", function.Parameters)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Invalid parameters for function '%v': %v", function.Name, err)
+		err = errors.New(errMsg)
+		return err
+	}
+
+	var parameters struct {
+		Type any `json:"type"`
+	}
+	json.Unmarshal(function.Parameters, &parameters)
+
+	if parameters.Type != "object" {
+		errMsg := fmt.Sprintf("Invalid parameters for function '%v': top-level type must be \"object\"", function.Name)
+		err = errors.New(errMsg)
+		return err
+	}
+
+	return nil
+}
+
+// ValidateTools validates every function tool in tools.
+func ValidateTools(tools []openai.Tool) error {
+	for i, tool := range tools {
+		if tool.Type != "function" {
+			continue
+		}
+
+		if tool.Function == nil {
+			errMsg := fmt.Sprintf("Invalid tool %v: function tool has no function", i+1)
+			err := errors.New(errMsg)
+			return err
+		}
+
+		err := ValidateFunction(tool.Function)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ToolInput reads a function tool from a JSON file holding either the function
+// definition itself or a whole {"type": "function", "function": {...}} tool.
+func ToolInput(fileName string) (*openai.Tool, error) {
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		err = errors.New("Failed reading tool file: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	var tool openai.Tool
+	err = json.Unmarshal(data, &tool)
+
+	if err != nil {
+		err = errors.New("Failed parsing tool file: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	if tool.Type == "" && tool.Function == nil { // Bare function definition
+		var function openai.Function
+		json.Unmarshal(data, &function)
+
+		tool = openai.Tool{Type: "function", Function: &function}
+	}
+
+	if tool.Type != "function" || tool.Function == nil {
+		errMsg := fmt.Sprintf("Invalid tool file: %v. Must hold a function definition.", fileName)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	err = ValidateFunction(tool.Function)
+
+	if err != nil {
+		err = errors.New("Invalid tool file: " + fileName + ". " + err.Error())
+		return nil, err
+	}
+
+	return &tool, nil
+}
+
+// JSONSchemaInput reads a json_schema response format from a JSON file holding either
+// {"name": ..., "schema": {...}, "strict": ...} or a bare schema, named after the file.
+func JSONSchemaInput(fileName string) (*openai.JSONSchema, error) {
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		err = errors.New("Failed reading schema file: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	var jsonSchema openai.JSONSchema
+	err = json.Unmarshal(data, &jsonSchema)
+
+	if err != nil {
+		err = errors.New("Failed parsing schema file: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	if len(jsonSchema.Schema) < 1 { // Bare schema
+		baseName := filepath.Base(fileName)
+		jsonSchema = openai.JSONSchema{
+			Name:   strings.TrimSuffix(baseName, filepath.Ext(baseName)),
+			Schema: data,
+		}
+	}
+
+	if !functionName.MatchString(jsonSchema.Name) {
+		errMsg := fmt.Sprintf("Invalid schema file: %v. Name '%v' must be 1-64 of a-z, A-Z, 0-9, '_' or '-'", fileName, jsonSchema.Name)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	err = validateSchema(jsonSchema.Name+".schema.json", jsonSchema.Schema)
+
+	if err != nil {
+		err = errors.New("Invalid schema file: " + fileName + ". " + err.Error())
+		return nil, err
+	}
+
+	return &jsonSchema, nil
+}
+
+// validateSchema checks raw is valid JSON Schema against the draft 2020-12 meta-schema.
+func validateSchema(url string, raw json.RawMessage) error {
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+
+	err := compiler.AddResource(url, bytes.NewReader(raw))
+
+	if err != nil {
+		return err
+	}
+
+	_, err = compiler.Compile(url)
+
+	if err != nil {
+		err = errors.New(describe(err))
+		return err
+	}
+
+	return nil
+}
+
+// describe flattens a schema validation error to its leaf causes, one per line.
+func describe(err error) string {
+	var schemaErr *jsonschema.SchemaError
+
+	if !errors.As(err, &schemaErr) {
+		return err.Error()
+	}
+
+	var validationErr *jsonschema.ValidationError
+
+	if !errors.As(schemaErr.Err, &validationErr) {
+		return schemaErr.Err.Error()
+	}
+
+	causes := []string{}
+	collectCauses(validationErr, &causes)
+
+	return "\n\t" + strings.Join(causes, "\n\t")
+}
+
+func collectCauses(validationErr *jsonschema.ValidationError, causes *[]string) {
+	if len(validationErr.Causes) < 1 {
+		location := validationErr.InstanceLocation
+
+		if location == "" {
+			location = "/"
+		}
+
+		*causes = append(*causes, fmt.Sprintf("at '%v': %v", location, validationErr.Message))
+		return
+	}
+
+	for _, cause := range validationErr.Causes {
+		collectCauses(cause, causes)
+	}
+}
@@ -0,0 +1,167 @@
+package search
+
+import (
+	"encoding/gob"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const IndexEnv = "OAIT_INDEX"
+
+// Doc is an indexed message.
+type Doc struct {
+	ThreadID  string
+	MessageID string
+	Role      string
+	CreatedAt int64
+	Text      string
+}
+
+// Posting lists where a term appears in a doc.
+type Posting struct {
+	Doc       int
+	Positions []int
+}
+
+// Index is an inverted index of messages: for each stemmed term, the messages it
+// appears in and where. Sources are the mirror and export files it was built from.
+type Index struct {
+	BuiltAt  int64 // unix nanoseconds, to compare with source mtimes
+	Sources  []string
+	Docs     []Doc
+	DocLens  []int
+	Postings map[string][]Posting
+}
+
+// Build indexes the docs.
+func Build(docs []Doc, sources []string) *Index {
+	ix := Index{
+		BuiltAt:  time.Now().UnixNano(),
+		Sources:  sources,
+		Docs:     docs,
+		DocLens:  make([]int, len(docs)),
+		Postings: make(map[string][]Posting),
+	}
+
+	for i, doc := range docs {
+		positions := make(map[string][]int)
+		tokens := tokenize(doc.Text)
+
+		for _, token := range tokens {
+			positions[token.term] = append(positions[token.term], token.pos)
+		}
+
+		for term, termPositions := range positions {
+			ix.Postings[term] = append(ix.Postings[term], Posting{i, termPositions})
+		}
+
+		ix.DocLens[i] = len(tokens)
+	}
+
+	return &ix
+}
+
+// Load reads the index (OAIT_INDEX, or <config dir>/oait/search.idx). A missing
+// index is nil.
+func Load() (*Index, error) {
+	fileName, err := Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(fileName)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		err = errors.New("Failed reading index: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+	defer file.Close()
+
+	var ix Index
+	err = gob.NewDecoder(file).Decode(&ix)
+
+	if err != nil {
+		err = errors.New("Failed reading index: " + fileName + ". Error: " + err.Error() + " (rebuild it with --reindex)")
+		return nil, err
+	}
+
+	return &ix, nil
+}
+
+// Save writes the index, readable only by the user as it holds message text.
+func (ix *Index) Save() error {
+	fileName, err := Path()
+
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(fileName), 0700)
+
+	if err != nil {
+		err = errors.New("Failed writing index: " + fileName + ". Error: " + err.Error())
+		return err
+	}
+
+	file, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+
+	if err == nil {
+		err = gob.NewEncoder(file).Encode(ix)
+		file.Close()
+	}
+
+	if err != nil {
+		err = errors.New("Failed writing index: " + fileName + ". Error: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Stale reports whether a source changed after the index was built.
+func (ix *Index) Stale() bool {
+	for _, source := range ix.Sources {
+		stat, err := os.Stat(source)
+
+		if err != nil || stat.ModTime().UnixNano() >= ix.BuiltAt {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NumThreads counts the threads indexed.
+func (ix *Index) NumThreads() int {
+	threads := make(map[string]bool)
+
+	for _, doc := range ix.Docs {
+		threads[doc.ThreadID] = true
+	}
+
+	return len(threads)
+}
+
+func Path() (string, error) {
+	fileName := os.Getenv(IndexEnv)
+
+	if fileName != "" {
+		return fileName, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+
+	if err != nil {
+		err = errors.New("Failed to locate index: " + err.Error())
+		return "", err
+	}
+
+	return filepath.Join(configDir, "oait", "search.idx"), nil
+}
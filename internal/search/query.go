@@ -0,0 +1,96 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Query is a parsed search: every term and phrase must appear in a thread (phrases
+// within one message), no excluded term may, and only messages from the given roles
+// (any, if none) count.
+type Query struct {
+	Terms    []string
+	Phrases  [][]string
+	Excluded []string
+	Roles    []string
+}
+
+// ParseQuery parses words, "quoted phrases", -excluded words and role:<role>
+// filters. A word that tokenizes to several terms (e.g. inv-4411) is a phrase.
+func ParseQuery(query string) (*Query, error) {
+	q := Query{}
+
+	for len(query) > 0 {
+		query = strings.TrimLeft(query, " \t\n")
+
+		if query == "" {
+			break
+		}
+
+		var part string
+		quoted := strings.HasPrefix(query, "\"")
+
+		if quoted {
+			end := strings.Index(query[1:], "\"")
+
+			if end < 0 {
+				errMsg := fmt.Sprintf("invalid query: unclosed quote in '%s'", query)
+				err := errors.New(errMsg)
+				return nil, err
+			}
+
+			part = query[1 : end+1]
+			query = query[end+2:]
+		} else {
+			end := strings.IndexAny(query, " \t\n")
+
+			if end < 0 {
+				end = len(query)
+			}
+
+			part = query[:end]
+			query = query[end:]
+		}
+
+		if !quoted && strings.HasPrefix(part, "role:") {
+			q.Roles = append(q.Roles, strings.ToLower(strings.TrimPrefix(part, "role:")))
+			continue
+		}
+
+		if !quoted && strings.HasPrefix(part, "-") && len(part) > 1 {
+			q.Excluded = append(q.Excluded, terms(part[1:])...)
+			continue
+		}
+
+		partTerms := terms(part)
+
+		if len(partTerms) == 1 {
+			q.Terms = append(q.Terms, partTerms[0])
+		} else if len(partTerms) > 1 {
+			q.Phrases = append(q.Phrases, partTerms)
+		}
+	}
+
+	if len(q.Terms) == 0 && len(q.Phrases) == 0 {
+		err := errors.New("invalid query: nothing to search for")
+		return nil, err
+	}
+
+	return &q, nil
+}
+
+// allows reports whether a message's role counts for the query.
+func (q *Query) allows(role string) bool {
+	if len(q.Roles) == 0 {
+		return true
+	}
+
+	for _, r := range q.Roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,275 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// BM25 parameters.
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+const (
+	snippetsPerThread = 2
+	snippetBefore     = 8
+	snippetAfter      = 16
+)
+
+type Result struct {
+	ThreadID string    `json:"thread_id"`
+	Score    float64   `json:"score"`
+	Matches  int       `json:"matches"`
+	Snippets []Snippet `json:"snippets"`
+}
+
+type Snippet struct {
+	MessageID string `json:"message_id"`
+	Role      string `json:"role"`
+	Text      string `json:"text"`
+}
+
+// threadHits are where a thread matched: the positions matched in each doc, and
+// per term or phrase (by index in the query's Terms then Phrases) how often.
+type threadHits struct {
+	docs map[int][]int // doc -> matched token positions, for snippets
+	tfs  []int         // occurrences of each term or phrase
+	len  int           // tokens in the thread's allowed docs
+}
+
+// Search ranks the threads matching the query by BM25 over their messages (treating
+// each phrase as a term), with snippets of their best matching messages.
+func (ix *Index) Search(q *Query, limit int) []Result {
+	numParts := len(q.Terms) + len(q.Phrases)
+	hits := make(map[string]*threadHits)
+	threadLens := make(map[string]int)
+	excluded := make(map[string]bool)
+
+	for i, doc := range ix.Docs {
+		if q.allows(doc.Role) {
+			threadLens[doc.ThreadID] += ix.DocLens[i]
+		}
+	}
+
+	for _, term := range q.Excluded {
+		for _, posting := range ix.Postings[term] {
+			if doc := ix.Docs[posting.Doc]; q.allows(doc.Role) {
+				excluded[doc.ThreadID] = true
+			}
+		}
+	}
+
+	// record counts occurrences of a term or phrase in a doc, at its token positions.
+	record := func(part int, docIndex int, positions []int, occurrences int) {
+		doc := ix.Docs[docIndex]
+
+		if excluded[doc.ThreadID] || !q.allows(doc.Role) || len(positions) == 0 {
+			return
+		}
+
+		h, ok := hits[doc.ThreadID]
+
+		if !ok {
+			h = &threadHits{make(map[int][]int), make([]int, numParts), threadLens[doc.ThreadID]}
+			hits[doc.ThreadID] = h
+		}
+
+		h.docs[docIndex] = append(h.docs[docIndex], positions...)
+		h.tfs[part] += occurrences
+	}
+
+	for i, term := range q.Terms {
+		for _, posting := range ix.Postings[term] {
+			record(i, posting.Doc, posting.Positions, len(posting.Positions))
+		}
+	}
+
+	for i, phrase := range q.Phrases {
+		for docIndex, starts := range ix.phraseStarts(phrase) {
+			positions := []int{}
+
+			for _, start := range starts {
+				for j := range phrase {
+					positions = append(positions, start+j)
+				}
+			}
+
+			record(len(q.Terms)+i, docIndex, positions, len(starts))
+		}
+	}
+
+	numThreads := float64(len(threadLens))
+	avgLen := 0.0
+
+	for _, threadLen := range threadLens {
+		avgLen += float64(threadLen) / max(numThreads, 1)
+	}
+
+	dfs := make([]int, numParts)
+
+	for _, h := range hits {
+		for part, tf := range h.tfs {
+			if tf > 0 {
+				dfs[part] += 1
+			}
+		}
+	}
+
+	results := []Result{}
+
+	for threadID, h := range hits {
+		score := 0.0
+		matchesAll := true
+
+		for part, tf := range h.tfs {
+			if tf == 0 {
+				matchesAll = false
+				break
+			}
+
+			idf := math.Log(1 + (numThreads-float64(dfs[part])+0.5)/(float64(dfs[part])+0.5))
+			norm := float64(tf) + k1*(1-b+b*float64(h.len)/max(avgLen, 1))
+			score += idf * float64(tf) * (k1 + 1) / norm
+		}
+
+		if !matchesAll {
+			continue
+		}
+
+		result := Result{
+			ThreadID: threadID,
+			Score:    math.Round(score*1000) / 1000,
+			Matches:  len(h.docs),
+			Snippets: ix.snippets(h),
+		}
+
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+
+		return results[i].ThreadID < results[j].ThreadID
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results
+}
+
+// phraseStarts finds where the phrase's terms appear consecutively, by doc.
+func (ix *Index) phraseStarts(phrase []string) map[int][]int {
+	positions := make([]map[int]map[int]bool, len(phrase))
+
+	for i, term := range phrase {
+		positions[i] = make(map[int]map[int]bool)
+
+		for _, posting := range ix.Postings[term] {
+			positions[i][posting.Doc] = make(map[int]bool, len(posting.Positions))
+
+			for _, pos := range posting.Positions {
+				positions[i][posting.Doc][pos] = true
+			}
+		}
+	}
+
+	starts := make(map[int][]int)
+
+	for _, posting := range ix.Postings[phrase[0]] {
+		for _, start := range posting.Positions {
+			matched := true
+
+			for i := 1; i < len(phrase) && matched; i++ {
+				matched = positions[i][posting.Doc][start+i]
+			}
+
+			if matched {
+				starts[posting.Doc] = append(starts[posting.Doc], start)
+			}
+		}
+	}
+
+	return starts
+}
+
+// snippets are excerpts of the thread's messages with the most hits, around their
+// first hit, with the matched words in **bold**.
+func (ix *Index) snippets(h *threadHits) []Snippet {
+	docs := make([]int, 0, len(h.docs))
+
+	for doc := range h.docs {
+		docs = append(docs, doc)
+	}
+
+	sort.Slice(docs, func(i, j int) bool {
+		if len(h.docs[docs[i]]) != len(h.docs[docs[j]]) {
+			return len(h.docs[docs[i]]) > len(h.docs[docs[j]])
+		}
+
+		return docs[i] < docs[j]
+	})
+
+	snippets := []Snippet{}
+
+	for _, docIndex := range docs[:min(len(docs), snippetsPerThread)] {
+		doc := ix.Docs[docIndex]
+		snippets = append(snippets, Snippet{doc.MessageID, doc.Role, highlight(doc.Text, h.docs[docIndex])})
+	}
+
+	return snippets
+}
+
+func highlight(text string, positions []int) string {
+	tokens := tokenize(text)
+	matched := make(map[int]bool, len(positions))
+	first := len(tokens)
+
+	for _, pos := range positions {
+		matched[pos] = true
+		first = min(first, pos)
+	}
+
+	if len(tokens) == 0 {
+		return ""
+	}
+
+	from := max(first-snippetBefore, 0)
+	to := min(first+snippetAfter, len(tokens)-1)
+
+	var builder strings.Builder
+	offset := tokens[from].start
+
+	if from > 0 {
+		builder.WriteString("...")
+	}
+
+	for i, token := range tokens[from : to+1] {
+		builder.WriteString(text[offset:token.start])
+
+		if matched[token.pos] && (i == 0 || !matched[token.pos-1]) {
+			builder.WriteString("**")
+		}
+
+		builder.WriteString(text[token.start:token.end])
+
+		if matched[token.pos] && !matched[token.pos+1] {
+			builder.WriteString("**")
+		}
+
+		offset = token.end
+	}
+
+	if to < len(tokens)-1 {
+		builder.WriteString("...")
+	} else {
+		builder.WriteString(text[offset:])
+	}
+
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
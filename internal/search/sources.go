@@ -0,0 +1,134 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/mirror"
+	"github.com/jackitaliano/oait/internal/openai"
+)
+
+// Rebuild indexes every message in the sources: the mirror (by its path), and
+// thread exports (`threads get -o` output, raw or pretty).
+func Rebuild(sources []string) (*Index, error) {
+	mirrorPath, err := mirror.Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	docs := []Doc{}
+	seen := make(map[string]bool)
+
+	for _, source := range sources {
+		var sourceDocs []Doc
+
+		if source == mirrorPath {
+			sourceDocs, err = mirrorDocs()
+		} else {
+			sourceDocs, err = exportDocs(source)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, doc := range sourceDocs {
+			if !seen[doc.MessageID] { // in the mirror and an export, or several exports
+				docs = append(docs, doc)
+				seen[doc.MessageID] = true
+			}
+		}
+	}
+
+	return Build(docs, sources), nil
+}
+
+// mirrorDocs are the messages of every mirrored thread, including deleted ones.
+func mirrorDocs() ([]Doc, error) {
+	m, err := mirror.Open(true)
+
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+
+	threadIDs, err := m.ThreadIDs(true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	threads, _, err := m.ThreadsMessages(threadIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	docs := []Doc{}
+
+	for i, thread := range *threads {
+		for _, message := range thread.Messages {
+			docs = append(docs, Doc{threadIDs[i], message.ID, message.Role, message.CreatedAt, message.GetText()})
+		}
+	}
+
+	return docs, nil
+}
+
+// exportDocs reads a `threads get` export: raw messages, or pretty threads (which
+// have no message IDs or dates).
+func exportDocs(fileName string) ([]Doc, error) {
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		err = errors.New("Failed reading export: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	var raw []openai.Messages
+	err = json.Unmarshal(data, &raw)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Failed parsing export: %v. Error: %v (should be `threads get` output)", fileName, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	docs := []Doc{}
+
+	for _, thread := range raw {
+		for _, message := range thread.Messages {
+			if message.ID == "" { // pretty, which parses as raw messages without content
+				continue
+			}
+
+			docs = append(docs, Doc{message.ThreadID, message.ID, message.Role, message.CreatedAt, message.GetText()})
+		}
+	}
+
+	if len(docs) > 0 {
+		return docs, nil
+	}
+
+	var pretty []io.Thread
+	err = json.Unmarshal(data, &pretty)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Failed parsing export: %v. Error: %v (should be `threads get` output)", fileName, err)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	for _, thread := range pretty {
+		for i, message := range thread.Messages {
+			messageID := fmt.Sprintf("%v#%v", thread.ThreadID, i)
+			docs = append(docs, Doc{thread.ThreadID, messageID, message.Role, 0, message.Text})
+		}
+	}
+
+	return docs, nil
+}
@@ -0,0 +1,63 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kljensen/snowball/english"
+)
+
+// token is a stemmed term, with its position among the text's tokens and its byte
+// offsets in the text (for snippets).
+type token struct {
+	term  string
+	pos   int
+	start int
+	end   int
+}
+
+// tokenize splits text into lowercased runs of letters and digits and stems them,
+// so "Invoices" and "invoice" match.
+func tokenize(text string) []token {
+	tokens := []token{}
+	start := -1
+
+	for i, r := range text + " " {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\''
+
+		if isWord && start < 0 {
+			start = i
+		} else if !isWord && start >= 0 {
+			word := strings.Trim(strings.ToLower(text[start:i]), "'")
+
+			if word != "" {
+				tokens = append(tokens, token{term: stem(word), pos: len(tokens), start: start, end: i})
+			}
+
+			start = -1
+		}
+	}
+
+	return tokens
+}
+
+func stem(word string) string {
+	if utf8.RuneCountInString(word) < 3 || strings.IndexFunc(word, unicode.IsDigit) >= 0 {
+		return word
+	}
+
+	return english.Stem(word, true)
+}
+
+// terms are the stemmed terms of text, in order.
+func terms(text string) []string {
+	tokens := tokenize(text)
+	terms := make([]string, len(tokens))
+
+	for i, token := range tokens {
+		terms[i] = token.term
+	}
+
+	return terms
+}
@@ -0,0 +1,109 @@
+package spec
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+)
+
+// FromAsst makes a portable spec of a live assistant, dropping its ID and timestamps.
+func FromAsst(asstObject openai.AsstObject) Asst {
+	s := Asst{
+		Name:          asstObject.Name,
+		Model:         asstObject.Model,
+		Tools:         asstObject.Tools,
+		ToolResources: asstObject.ToolResources,
+		ResFormat:     asstObject.ResFormat,
+		Temp:          &asstObject.Temp,
+		TopP:          &asstObject.TopP,
+		Metadata:      asstObject.Metadata,
+	}
+
+	if asstObject.Description != "" {
+		s.Description = &asstObject.Description
+	}
+
+	if asstObject.Instructions != "" {
+		s.Instructions = &asstObject.Instructions
+	}
+
+	return s
+}
+
+// Export writes a <name>.json spec per assistant to dir, with its instructions in
+// <name>.md beside it. Assistants with no name, or a name already taken, use their ID.
+func Export(dir string, asstObjects []openai.AsstObject) ([]string, error) {
+	err := os.MkdirAll(dir, 0755)
+
+	if err != nil {
+		err = errors.New("Failed to create export directory: " + dir + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	fileNames := []string{}
+	taken := make(map[string]bool)
+
+	for _, asstObject := range asstObjects {
+		baseName := slug(asstObject.Name)
+
+		if baseName == "" || taken[baseName] {
+			baseName = asstObject.ID
+		}
+		taken[baseName] = true
+
+		s := FromAsst(asstObject)
+
+		if s.Instructions != nil {
+			instructionsFile := baseName + ".md"
+			instructions := []byte(*s.Instructions)
+
+			err = io.FileOutput(filepath.Join(dir, instructionsFile), &instructions)
+
+			if err != nil {
+				return nil, err
+			}
+
+			s.Instructions = nil
+			s.InstructionsFile = instructionsFile
+		}
+
+		specOutput, err := io.ObjToJSON(&s)
+
+		if err != nil {
+			return nil, err
+		}
+
+		fileName := filepath.Join(dir, baseName+".json")
+		err = io.FileOutput(fileName, &specOutput)
+
+		if err != nil {
+			return nil, err
+		}
+
+		fileNames = append(fileNames, fileName)
+	}
+
+	return fileNames, nil
+}
+
+func slug(name string) string {
+	var b strings.Builder
+	dash := false
+
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,176 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/diff"
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/protect"
+)
+
+const (
+	Create = "create"
+	Update = "update"
+	NoOp   = "no-op"
+	Delete = "delete"
+	Keep   = "keep"
+)
+
+type Change struct {
+	Action   string
+	Spec     *Asst
+	Live     *openai.AsstObject
+	Modified *openai.ModifiedAssistant
+	Diff     []string
+	Reason   string // Why a Keep is protected from pruning
+}
+
+// Plan matches each spec to a live assistant by name, or by the value of the
+// matchKey metadata key if given, and works out what applying the specs would do.
+// With prune, live assistants no spec matches are deleted; with a matchKey only
+// those carrying the key are considered managed, and those the policy protects
+// are kept instead.
+func Plan(specs []Asst, asstObjects []openai.AsstObject, matchKey string, prune bool, policy *protect.Policy) ([]Change, error) {
+	liveByKey := make(map[string]*openai.AsstObject)
+
+	for i := range asstObjects {
+		asstObject := &asstObjects[i]
+		liveKey, ok := getMatchKey(asstObject.Name, asstObject.Metadata, matchKey)
+
+		if !ok {
+			continue
+		}
+
+		other, ok := liveByKey[liveKey]
+
+		if ok {
+			errMsg := fmt.Sprintf("Ambiguous match '%v': live assistants %v and %v both match", liveKey, other.ID, asstObject.ID)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+
+		liveByKey[liveKey] = asstObject
+	}
+
+	changes := []Change{}
+	matched := make(map[string]bool)
+
+	for i := range specs {
+		spec := &specs[i]
+		specKey, ok := getMatchKey(spec.Name, spec.Metadata, matchKey)
+
+		if !ok {
+			errMsg := fmt.Sprintf("Invalid spec: %v. Missing metadata key '%v' to match on.", spec.Source, matchKey)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+
+		if matched[specKey] {
+			errMsg := fmt.Sprintf("Duplicate spec '%v' in %v", specKey, spec.Source)
+			err := errors.New(errMsg)
+			return nil, err
+		}
+		matched[specKey] = true
+
+		live, ok := liveByKey[specKey]
+
+		if !ok {
+			changes = append(changes, Change{Action: Create, Spec: spec})
+			continue
+		}
+
+		patched, modified := spec.Patch(*live)
+		asstChanges := diff.Assistants(live, patched)
+
+		if len(asstChanges) < 1 {
+			changes = append(changes, Change{Action: NoOp, Spec: spec, Live: live})
+			continue
+		}
+
+		changes = append(changes, Change{Action: Update, Spec: spec, Live: live, Modified: modified, Diff: asstChanges})
+	}
+
+	if prune {
+		for i := range asstObjects {
+			asstObject := &asstObjects[i]
+			liveKey, ok := getMatchKey(asstObject.Name, asstObject.Metadata, matchKey)
+
+			if !ok || matched[liveKey] {
+				continue
+			}
+
+			target := protect.Target{ID: asstObject.ID, Name: asstObject.Name, Metadata: asstObject.Metadata}
+			reason, protected := policy.Match(target)
+
+			if protected {
+				changes = append(changes, Change{Action: Keep, Live: asstObject, Reason: reason})
+				continue
+			}
+
+			changes = append(changes, Change{Action: Delete, Live: asstObject})
+		}
+	}
+
+	return changes, nil
+}
+
+// Format renders the plan Terraform-style, one block per change and a summary line.
+func Format(changes []Change) string {
+	lines := []string{}
+	counts := make(map[string]int)
+
+	for _, change := range changes {
+		counts[change.Action]++
+
+		switch change.Action {
+		case Create:
+			lines = append(lines, fmt.Sprintf("+ create %q (%v)", change.Spec.Name, change.Spec.Source))
+		case Update:
+			lines = append(lines, fmt.Sprintf("~ update %q %v (%v)", change.Spec.Name, change.Live.ID, change.Spec.Source))
+
+			for _, line := range change.Diff {
+				lines = append(lines, "    "+line)
+			}
+		case NoOp:
+			lines = append(lines, fmt.Sprintf("= no-op  %q %v", change.Spec.Name, change.Live.ID))
+		case Delete:
+			lines = append(lines, fmt.Sprintf("- delete %q %v", change.Live.Name, change.Live.ID))
+		case Keep:
+			lines = append(lines, fmt.Sprintf("! keep   %q %v (protected: %v)", change.Live.Name, change.Live.ID, change.Reason))
+		}
+	}
+
+	lines = append(lines, "")
+	summary := fmt.Sprintf("Plan: %v to create, %v to update, %v to delete.", counts[Create], counts[Update], counts[Delete])
+
+	if counts[Keep] > 0 {
+		summary += fmt.Sprintf(" %v protected from pruning.", counts[Keep])
+	}
+
+	lines = append(lines, summary)
+
+	return strings.Join(lines, "\n")
+}
+
+// HasChanges reports whether applying the plan would change anything.
+func HasChanges(changes []Change) bool {
+	for _, change := range changes {
+		if change.Action != NoOp && change.Action != Keep {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getMatchKey(name string, metadata map[string]string, matchKey string) (string, bool) {
+	if matchKey == "" {
+		return name, name != ""
+	}
+
+	val, ok := metadata[matchKey]
+
+	return val, ok && val != ""
+}
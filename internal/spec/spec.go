@@ -0,0 +1,215 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/schema"
+)
+
+type Asst struct {
+	Name             string                         `json:"name"`
+	Description      *string                        `json:"description,omitempty"`
+	Instructions     *string                        `json:"instructions,omitempty"`
+	InstructionsFile string                         `json:"instructions_file,omitempty"`
+	Model            string                         `json:"model"`
+	Tools            []openai.Tool                  `json:"tools,omitempty"`
+	ToolResources    map[string]map[string][]string `json:"tool_resources,omitempty"`
+	ResFormat        *openai.ResponseFormat         `json:"response_format,omitempty"`
+	Temp             *float64                       `json:"temperature,omitempty"`
+	TopP             *float64                       `json:"top_p,omitempty"`
+	Metadata         map[string]string              `json:"metadata,omitempty"`
+
+	Source string `json:"-"`
+}
+
+// Load reads assistant specs from a .json/.yaml/.yml file or every such file in a directory.
+func Load(path string) ([]Asst, error) {
+	info, err := os.Stat(path)
+
+	if err != nil {
+		err = errors.New("Failed reading specs: " + path + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	fileNames := []string{path}
+
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+
+		if err != nil {
+			err = errors.New("Failed reading specs: " + path + ". Error: " + err.Error())
+			return nil, err
+		}
+
+		fileNames = []string{}
+
+		for _, entry := range entries {
+			if !entry.IsDir() && isSpecFile(entry.Name()) {
+				fileNames = append(fileNames, filepath.Join(path, entry.Name()))
+			}
+		}
+
+		sort.Strings(fileNames)
+	}
+
+	specs := []Asst{}
+
+	for _, fileName := range fileNames {
+		spec, err := loadFile(fileName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		specs = append(specs, *spec)
+	}
+
+	if len(specs) < 1 {
+		errMsg := fmt.Sprintf("No assistant specs found in '%v'", path)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	return specs, nil
+}
+
+func (s Asst) ToCreated() *openai.CreatedAssistant {
+	createdAsst := openai.CreatedAssistant{
+		Name:          s.Name,
+		Model:         s.Model,
+		Tools:         s.Tools,
+		ToolResources: s.ToolResources,
+		ResFormat:     s.ResFormat,
+		Temp:          s.Temp,
+		TopP:          s.TopP,
+		Metadata:      s.Metadata,
+	}
+
+	if s.Description != nil {
+		createdAsst.Description = *s.Description
+	}
+
+	if s.Instructions != nil {
+		createdAsst.Instructions = *s.Instructions
+	}
+
+	return &createdAsst
+}
+
+// Patch overlays the fields set in the spec onto a live assistant, returning the
+// patched assistant (for diffing) and the fields to send. Unset fields are left alone.
+func (s Asst) Patch(asstObject openai.AsstObject) (*openai.AsstObject, *openai.ModifiedAssistant) {
+	patched := asstObject
+	modified := openai.ModifiedAssistant{}
+
+	patched.Name = s.Name
+	modified.Name = &s.Name
+
+	patched.Model = s.Model
+	modified.Model = &s.Model
+
+	if s.Description != nil {
+		patched.Description = *s.Description
+		modified.Description = s.Description
+	}
+
+	if s.Instructions != nil {
+		patched.Instructions = *s.Instructions
+		modified.Instructions = s.Instructions
+	}
+
+	if s.Tools != nil {
+		patched.Tools = s.Tools
+		modified.Tools = &s.Tools
+	}
+
+	if s.ToolResources != nil {
+		patched.ToolResources = s.ToolResources
+		modified.ToolResources = s.ToolResources
+	}
+
+	if s.ResFormat != nil {
+		patched.ResFormat = s.ResFormat
+		modified.ResFormat = s.ResFormat
+	}
+
+	if s.Temp != nil {
+		patched.Temp = *s.Temp
+		modified.Temp = s.Temp
+	}
+
+	if s.TopP != nil {
+		patched.TopP = *s.TopP
+		modified.TopP = s.TopP
+	}
+
+	if s.Metadata != nil {
+		patched.Metadata = s.Metadata
+		modified.Metadata = s.Metadata
+	}
+
+	return &patched, &modified
+}
+
+func isSpecFile(fileName string) bool {
+	return strings.HasSuffix(fileName, ".json") || strings.HasSuffix(fileName, ".yaml") || strings.HasSuffix(fileName, ".yml")
+}
+
+func loadFile(fileName string) (*Asst, error) {
+	var spec *Asst
+	var err error
+
+	if strings.HasSuffix(fileName, ".json") {
+		spec, err = io.JSONInput[Asst](fileName)
+	} else {
+		spec, err = io.YAMLInput[Asst](fileName)
+	}
+
+	if err != nil {
+		err = errors.New("Failed loading spec: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	spec.Source = fileName
+
+	if spec.Name == "" || spec.Model == "" {
+		err = errors.New("Invalid spec: " + fileName + ". Must provide a name and a model.")
+		return nil, err
+	}
+
+	err = schema.ValidateTools(spec.Tools)
+
+	if err != nil {
+		err = errors.New("Invalid spec: " + fileName + ". " + err.Error())
+		return nil, err
+	}
+
+	if spec.InstructionsFile != "" {
+		if spec.Instructions != nil {
+			err = errors.New("Invalid spec: " + fileName + ". Give only one of instructions and instructions_file.")
+			return nil, err
+		}
+
+		instructionsFile := spec.InstructionsFile
+		if !filepath.IsAbs(instructionsFile) { // Relative to the spec file
+			instructionsFile = filepath.Join(filepath.Dir(fileName), instructionsFile)
+		}
+
+		instructions, err := io.TextInput(instructionsFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		spec.Instructions = &instructions
+	}
+
+	return spec, nil
+}
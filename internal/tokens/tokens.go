@@ -0,0 +1,11 @@
+package tokens
+
+import (
+	"unicode/utf8"
+)
+
+// Estimate approximates the number of tokens in text at ~4 characters per token,
+// which is close for English with OpenAI's tokenizers. It's for budgeting, not billing.
+func Estimate(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
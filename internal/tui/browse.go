@@ -0,0 +1,476 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type mode int
+
+const (
+	listMode mode = iota
+	filterMode
+	detailMode
+	confirmMode
+)
+
+const (
+	reverseVideo = "\x1b[7m"
+	bold         = "\x1b[1m"
+	resetStyle   = "\x1b[0m"
+)
+
+// view is where a resource's list is at: its filter, the items it lets through, and
+// the cursor and scroll among those.
+type view struct {
+	filter  string
+	visible []int
+	cursor  int
+	offset  int
+}
+
+type browser struct {
+	resources []Resource
+	views     []view
+	marked    []map[string]bool
+	tab       int
+	mode      mode
+
+	detail       []string // wrapped lines of the open item
+	detailIndex  int
+	detailOffset int
+
+	width     int
+	height    int
+	confirmed bool
+}
+
+// Browse shows the resources full-screen, to filter, read and mark for deletion.
+// It returns the IDs marked, by resource name, once their deletion is confirmed.
+func Browse(resources []Resource) (map[string][]string, error) {
+	if !IsInteractive() {
+		err := errors.New("Cannot browse: stdin is not a terminal")
+		return nil, err
+	}
+
+	b := browser{resources: resources, width: 80, height: 24}
+
+	for _, resource := range resources {
+		b.views = append(b.views, view{visible: resource.Filter(nil)})
+		b.marked = append(b.marked, make(map[string]bool))
+	}
+
+	final, err := tea.NewProgram(&b, tea.WithAltScreen()).Run()
+
+	if err != nil {
+		err = errors.New("Browse failed with error: " + err.Error())
+		return nil, err
+	}
+
+	b = *final.(*browser)
+
+	if !b.confirmed {
+		return nil, nil
+	}
+
+	return b.markedIDs(), nil
+}
+
+func (b *browser) Init() tea.Cmd {
+	return nil
+}
+
+func (b *browser) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		b.width = msg.Width
+		b.height = msg.Height
+
+		if b.mode == detailMode {
+			b.openDetail(b.detailIndex)
+		}
+
+		b.scroll()
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return b, tea.Quit
+		}
+
+		switch b.mode {
+		case listMode:
+			return b, b.updateList(msg)
+		case filterMode:
+			b.updateFilter(msg)
+		case detailMode:
+			b.updateDetail(msg)
+		case confirmMode:
+			return b, b.updateConfirm(msg)
+		}
+	}
+
+	return b, nil
+}
+
+func (b *browser) updateList(msg tea.KeyMsg) tea.Cmd {
+	v := &b.views[b.tab]
+
+	switch msg.String() {
+	case "q":
+		return tea.Quit
+	case "esc":
+		if v.filter == "" {
+			return tea.Quit
+		}
+
+		v.filter = ""
+		v.visible = b.resources[b.tab].Filter(nil)
+	case "up", "k":
+		v.cursor -= 1
+	case "down", "j":
+		v.cursor += 1
+	case "pgup":
+		v.cursor -= b.listHeight()
+	case "pgdown":
+		v.cursor += b.listHeight()
+	case "home", "g":
+		v.cursor = 0
+	case "end", "G":
+		v.cursor = len(v.visible) - 1
+	case "tab", "right", "l":
+		b.tab = (b.tab + 1) % len(b.resources)
+	case "shift+tab", "left", "h":
+		b.tab = (b.tab + len(b.resources) - 1) % len(b.resources)
+	case "/":
+		b.mode = filterMode
+	case " ", "x":
+		if len(v.visible) > 0 {
+			b.toggleMark(v.visible[v.cursor])
+			v.cursor += 1
+		}
+	case "enter":
+		if len(v.visible) > 0 {
+			b.openDetail(v.visible[v.cursor])
+			b.mode = detailMode
+		}
+	case "d":
+		if b.numMarked() > 0 {
+			b.mode = confirmMode
+		}
+	}
+
+	b.scroll()
+
+	return nil
+}
+
+// updateFilter edits the filter, narrowing the list as it's typed.
+func (b *browser) updateFilter(msg tea.KeyMsg) {
+	v := &b.views[b.tab]
+
+	switch msg.Type {
+	case tea.KeyEnter:
+		b.mode = listMode
+	case tea.KeyEsc:
+		v.filter = ""
+		b.mode = listMode
+	case tea.KeyBackspace:
+		_, size := utf8.DecodeLastRuneInString(v.filter)
+		v.filter = v.filter[:len(v.filter)-size]
+	case tea.KeySpace:
+		v.filter += " "
+	case tea.KeyRunes:
+		v.filter += string(msg.Runes)
+	default:
+		return
+	}
+
+	v.visible = b.resources[b.tab].Filter(strings.Fields(v.filter))
+	v.cursor = 0
+	v.offset = 0
+}
+
+func (b *browser) updateDetail(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "q", "esc", "enter", "backspace":
+		b.mode = listMode
+	case "up", "k":
+		b.detailOffset -= 1
+	case "down", "j":
+		b.detailOffset += 1
+	case "pgup", "b":
+		b.detailOffset -= b.detailHeight()
+	case "pgdown", "f", " ":
+		b.detailOffset += b.detailHeight()
+	case "home", "g":
+		b.detailOffset = 0
+	case "end", "G":
+		b.detailOffset = len(b.detail)
+	case "x":
+		b.toggleMark(b.detailIndex)
+	}
+
+	b.detailOffset = max(min(b.detailOffset, len(b.detail)-b.detailHeight()), 0)
+}
+
+func (b *browser) updateConfirm(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "y", "Y":
+		b.confirmed = true
+		return tea.Quit
+	case "n", "N", "q", "esc":
+		b.mode = listMode
+	}
+
+	return nil
+}
+
+func (b *browser) toggleMark(i int) {
+	id := b.resources[b.tab].ID(i)
+	marked := b.marked[b.tab]
+
+	if marked[id] {
+		delete(marked, id)
+	} else {
+		marked[id] = true
+	}
+}
+
+func (b *browser) openDetail(i int) {
+	b.detailIndex = i
+	b.detail = wrap(b.resources[b.tab].Detail(i), b.width)
+	b.detailOffset = 0
+}
+
+// scroll keeps the cursor in the list and on screen.
+func (b *browser) scroll() {
+	v := &b.views[b.tab]
+	height := b.listHeight()
+
+	v.cursor = max(min(v.cursor, len(v.visible)-1), 0)
+
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	} else if v.cursor >= v.offset+height {
+		v.offset = v.cursor - height + 1
+	}
+}
+
+// listHeight leaves room for the tabs, filter and help lines.
+func (b *browser) listHeight() int {
+	return max(b.height-4, 1)
+}
+
+// detailHeight leaves room for the title and help lines.
+func (b *browser) detailHeight() int {
+	return max(b.height-2, 1)
+}
+
+func (b *browser) numMarked() int {
+	numMarked := 0
+
+	for _, marked := range b.marked {
+		numMarked += len(marked)
+	}
+
+	return numMarked
+}
+
+// markedIDs are in list order, by resource name.
+func (b *browser) markedIDs() map[string][]string {
+	ids := make(map[string][]string)
+
+	for r, resource := range b.resources {
+		for i := 0; i < resource.Len(); i++ {
+			if id := resource.ID(i); b.marked[r][id] {
+				ids[resource.Name()] = append(ids[resource.Name()], id)
+			}
+		}
+	}
+
+	return ids
+}
+
+func (b *browser) View() string {
+	switch b.mode {
+	case detailMode:
+		return b.detailView()
+	case confirmMode:
+		return b.confirmView()
+	}
+
+	return b.listView()
+}
+
+func (b *browser) listView() string {
+	var builder strings.Builder
+	v := b.views[b.tab]
+	resource := b.resources[b.tab]
+
+	builder.WriteString(b.tabsLine() + "\n")
+
+	if b.mode == filterMode {
+		builder.WriteString(truncate("filter: "+v.filter+"█", b.width) + "\n")
+	} else if v.filter != "" {
+		builder.WriteString(truncate(fmt.Sprintf("filter: %v (%v of %v)", v.filter, len(v.visible), resource.Len()), b.width) + "\n")
+	} else {
+		builder.WriteString("\n")
+	}
+
+	end := min(v.offset+b.listHeight(), len(v.visible))
+
+	for row := v.offset; row < end; row++ {
+		i := v.visible[row]
+		mark := "  "
+
+		if b.marked[b.tab][resource.ID(i)] {
+			mark = "✗ "
+		}
+
+		line := truncate(mark+resource.Row(i), b.width)
+
+		if row == v.cursor {
+			line = reverseVideo + line + strings.Repeat(" ", max(b.width-utf8.RuneCountInString(line), 0)) + resetStyle
+		}
+
+		builder.WriteString(line + "\n")
+	}
+
+	if len(v.visible) == 0 {
+		builder.WriteString(fmt.Sprintf("  No %v.\n", resource.Name()))
+		end += 1
+	}
+
+	builder.WriteString(strings.Repeat("\n", max(b.listHeight()-(end-v.offset), 0)))
+
+	help := "↑/↓ move · enter open · space mark · / filter · tab switch · q quit"
+
+	if b.mode == filterMode {
+		help = "type to filter · enter keep · esc clear"
+	} else if b.numMarked() > 0 {
+		help = fmt.Sprintf("%v marked · d delete · %v", b.numMarked(), help)
+	}
+
+	builder.WriteString(truncate(help, b.width))
+
+	return builder.String()
+}
+
+func (b *browser) tabsLine() string {
+	tabs := []string{}
+
+	for r, resource := range b.resources {
+		tab := fmt.Sprintf(" %v (%v) ", resource.Name(), resource.Len())
+
+		if r == b.tab {
+			tab = bold + reverseVideo + tab + resetStyle
+		}
+
+		tabs = append(tabs, tab)
+	}
+
+	return "oait browse  " + strings.Join(tabs, " ")
+}
+
+func (b *browser) detailView() string {
+	var builder strings.Builder
+	id := b.resources[b.tab].ID(b.detailIndex)
+
+	title := id
+
+	if b.marked[b.tab][id] {
+		title += "  ✗ marked"
+	}
+
+	builder.WriteString(bold + truncate(title, b.width) + resetStyle + "\n")
+
+	end := min(b.detailOffset+b.detailHeight(), len(b.detail))
+
+	for _, line := range b.detail[b.detailOffset:end] {
+		builder.WriteString(line + "\n")
+	}
+
+	builder.WriteString(strings.Repeat("\n", max(b.detailHeight()-(end-b.detailOffset), 0)))
+
+	position := fmt.Sprintf("%v-%v of %v", min(b.detailOffset+1, end), end, len(b.detail))
+	builder.WriteString(truncate("↑/↓ scroll · x mark · esc back · "+position, b.width))
+
+	return builder.String()
+}
+
+func (b *browser) confirmView() string {
+	var builder strings.Builder
+	ids := b.markedIDs()
+	lines := []string{}
+
+	for _, resource := range b.resources {
+		for _, id := range ids[resource.Name()] {
+			lines = append(lines, fmt.Sprintf("  %-8v %v", resource.Name(), id))
+		}
+	}
+
+	builder.WriteString(bold + fmt.Sprintf("Delete %v marked items?", len(lines)) + resetStyle + "\n\n")
+
+	height := max(b.height-4, 1)
+
+	for i, line := range lines {
+		if i == height-1 && len(lines) > height {
+			builder.WriteString(fmt.Sprintf("  ... and %v more\n", len(lines)-i))
+			break
+		}
+
+		builder.WriteString(truncate(line, b.width) + "\n")
+	}
+
+	builder.WriteString(strings.Repeat("\n", max(height-len(lines), 0)))
+	builder.WriteString("y delete · n back")
+
+	return builder.String()
+}
+
+func truncate(line string, width int) string {
+	if utf8.RuneCountInString(line) <= width {
+		return line
+	}
+
+	runes := []rune(line)
+
+	return string(runes[:max(width-1, 0)]) + "…"
+}
+
+// wrap breaks text into lines of at most width runes, at spaces where it can.
+func wrap(text string, width int) []string {
+	lines := []string{}
+	width = max(width, 10)
+
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\t", "    "), "\n") {
+		runes := []rune(strings.TrimRight(paragraph, " \r"))
+
+		for len(runes) > width {
+			end := width
+
+			for end > width/2 && runes[end] != ' ' {
+				end -= 1
+			}
+
+			if runes[end] != ' ' {
+				end = width
+			}
+
+			lines = append(lines, string(runes[:end]))
+			runes = runes[end:]
+
+			if len(runes) > 0 && runes[0] == ' ' {
+				runes = runes[1:]
+			}
+		}
+
+		lines = append(lines, string(runes))
+	}
+
+	return lines
+}
@@ -0,0 +1,216 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackitaliano/oait/internal/filter"
+	"github.com/jackitaliano/oait/internal/io"
+	"github.com/jackitaliano/oait/internal/openai"
+	"github.com/jackitaliano/oait/internal/spec"
+)
+
+// Resource is a list of threads, files or assistants to browse.
+type Resource interface {
+	Name() string
+	Len() int
+	ID(i int) string
+	Row(i int) string
+	Detail(i int) string
+	// Filter gives the items matching every word, as the get commands' -c (threads)
+	// and -n (files, assts) do.
+	Filter(words []string) []int
+}
+
+// Thread is a thread's messages with its ID, which empty threads don't otherwise have.
+type Thread struct {
+	ID string
+	openai.Messages
+}
+
+type resource[T any] struct {
+	name   string
+	items  []T
+	id     func(T) string
+	row    func(T) string
+	detail func(T) string
+	filter func(*[]T, []string) *[]T
+}
+
+func (r *resource[T]) Name() string {
+	return r.name
+}
+
+func (r *resource[T]) Len() int {
+	return len(r.items)
+}
+
+func (r *resource[T]) ID(i int) string {
+	return r.id(r.items[i])
+}
+
+func (r *resource[T]) Row(i int) string {
+	return r.row(r.items[i])
+}
+
+func (r *resource[T]) Detail(i int) string {
+	return r.detail(r.items[i])
+}
+
+func (r *resource[T]) Filter(words []string) []int {
+	indexes := []int{}
+
+	if len(words) == 0 {
+		for i := range r.items {
+			indexes = append(indexes, i)
+		}
+
+		return indexes
+	}
+
+	matched := make(map[string]bool)
+
+	for _, item := range *r.filter(&r.items, words) {
+		matched[r.id(item)] = true
+	}
+
+	for i, item := range r.items {
+		if matched[r.id(item)] {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+func ThreadsResource(threads []Thread) Resource {
+	return &resource[Thread]{
+		name:   "threads",
+		items:  threads,
+		id:     func(t Thread) string { return t.ID },
+		row:    threadRow,
+		detail: threadTranscript,
+		filter: filter.ContainsContent[Thread],
+	}
+}
+
+func FilesResource(fileObjects []openai.FileObject) Resource {
+	return &resource[openai.FileObject]{
+		name:   "files",
+		items:  fileObjects,
+		id:     func(f openai.FileObject) string { return f.ID },
+		row:    fileRow,
+		detail: fileDetail,
+		filter: filter.ContainsName[openai.FileObject],
+	}
+}
+
+func AsstsResource(asstObjects []openai.AsstObject) Resource {
+	return &resource[openai.AsstObject]{
+		name:   "assts",
+		items:  asstObjects,
+		id:     func(a openai.AsstObject) string { return a.ID },
+		row:    asstRow,
+		detail: asstConfig,
+		filter: filter.ContainsName[openai.AsstObject],
+	}
+}
+
+func threadRow(t Thread) string {
+	messages := sortedMessages(t.Messages)
+	createdAt := int64(0)
+	preview := ""
+
+	if len(messages) > 0 {
+		createdAt = messages[0].CreatedAt
+	}
+
+	for _, message := range messages {
+		if text := message.GetText(); text != "" {
+			preview = strings.Join(strings.Fields(text), " ")
+			break
+		}
+	}
+
+	return fmt.Sprintf("%v  %v  %3v msgs  %v", t.ID, formatTime(createdAt), len(messages), preview)
+}
+
+// threadTranscript is the thread's messages, oldest first.
+func threadTranscript(t Thread) string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "Thread %v (%v messages)\n", t.ID, t.GetLen())
+
+	for _, message := range sortedMessages(t.Messages) {
+		fmt.Fprintf(&builder, "\n── %v · %v ──\n", message.Role, formatTime(message.CreatedAt))
+
+		for _, content := range message.Content {
+			if content.Type == "text" {
+				builder.WriteString(content.Text.Value + "\n")
+			} else {
+				fmt.Fprintf(&builder, "[%v]\n", content.Type)
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+// sortedMessages are oldest first: the API lists them newest first, the mirror oldest first.
+func sortedMessages(messages openai.Messages) []openai.Message {
+	sorted := append([]openai.Message{}, messages.Messages...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt < sorted[j].CreatedAt
+	})
+
+	return sorted
+}
+
+func fileRow(f openai.FileObject) string {
+	return fmt.Sprintf("%v  %v  %-12v %10v B  %v", f.ID, formatTime(f.Created), f.Purpose, f.Bytes, f.Filename)
+}
+
+func fileDetail(f openai.FileObject) string {
+	output, err := io.ObjToJSON(&f)
+
+	if err != nil {
+		return err.Error()
+	}
+
+	return fmt.Sprintf("File %v\n\n%v\n", f.ID, string(output))
+}
+
+func asstRow(a openai.AsstObject) string {
+	return fmt.Sprintf("%v  %v  %-16v %v", a.ID, formatTime(a.CreatedAt), a.Model, a.Name)
+}
+
+// asstConfig is the assistant as `assts export` writes it: a spec, with its
+// instructions after.
+func asstConfig(a openai.AsstObject) string {
+	s := spec.FromAsst(a)
+	s.Instructions = nil
+	output, err := io.ObjToJSON(&s)
+
+	if err != nil {
+		return err.Error()
+	}
+
+	config := fmt.Sprintf("Assistant %v\n\n%v\n", a.ID, string(output))
+
+	if a.Instructions != "" {
+		config += "\n── instructions ──\n" + a.Instructions + "\n"
+	}
+
+	return config
+}
+
+func formatTime(createdAt int64) string {
+	if createdAt == 0 {
+		return strings.Repeat(" ", len(time.DateTime)-3)
+	}
+
+	return time.Unix(createdAt, 0).Format(time.DateTime)[:len(time.DateTime)-3]
+}
@@ -2,21 +2,31 @@ package tui
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
 	"strings"
 )
 
-func YesNoLoop(question string) bool {
+const AssumeYesEnv = "OAIT_ASSUME_YES"
+
+func YesNo(question string) (bool, error) {
 	yesRegexp, _ := regexp.Compile("[yY][eE]?[sS]?")
 	noRegexp, _ := regexp.Compile("[nN][oO]?")
 
+	if !IsInteractive() {
+		errMsg := fmt.Sprintf("Cannot prompt '%v': stdin is not a terminal (use --yes, --no-verify or %v=1)", question, AssumeYesEnv)
+		err := errors.New(errMsg)
+		return false, err
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+
 	for {
 		fmt.Printf("%v (y/n):", question)
 
-		reader := bufio.NewReader(os.Stdin)
-		text, _ := reader.ReadString('\n')
+		text, err := reader.ReadString('\n')
 		text = strings.Replace(text, "\n", "", -1)
 		text = strings.Replace(text, "\r\n", "", -1)
 
@@ -24,11 +34,50 @@ func YesNoLoop(question string) bool {
 		matchNo := noRegexp.MatchString(text)
 
 		if matchYes {
-			return true
+			return true, nil
 
 		} else if matchNo {
-			return false
+			return false, nil
 
+		} else if err != nil {
+			fmt.Printf("\n")
+			errMsg := fmt.Sprintf("Cannot prompt '%v': %v", question, err)
+			err = errors.New(errMsg)
+			return false, err
 		}
 	}
 }
+
+// Confirm answers yes without prompting when assumeYes is set or OAIT_ASSUME_YES is truthy.
+func Confirm(question string, assumeYes bool) (bool, error) {
+	if ConfirmedBy(assumeYes) != "prompt" {
+		fmt.Printf("%v (y/n):y (assumed)\n", question)
+		return true, nil
+	}
+
+	return YesNo(question)
+}
+
+// ConfirmedBy reports how a confirmation is answered: "flag", "env" or "prompt".
+func ConfirmedBy(assumeYes bool) string {
+	if assumeYes {
+		return "flag"
+	}
+
+	switch strings.ToLower(strings.Trim(os.Getenv(AssumeYesEnv), " ")) {
+	case "1", "y", "yes", "true":
+		return "env"
+	}
+
+	return "prompt"
+}
+
+func IsInteractive() bool {
+	stat, err := os.Stdin.Stat()
+
+	if err != nil {
+		return false
+	}
+
+	return stat.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,286 @@
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+)
+
+const (
+	VaultEnv      = "OAIT_VAULT"
+	PassphraseEnv = "OAIT_VAULT_PASSPHRASE"
+
+	version = 1
+
+	// scrypt parameters, as recommended for interactive logins (~100ms, 32MB).
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// vaultFile is the vault on disk. The keys are sealed together with AES-256-GCM,
+// under a key derived from the passphrase with scrypt.
+type vaultFile struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	N       int    `json:"n"`
+	R       int    `json:"r"`
+	P       int    `json:"p"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// Load decrypts the vault (OAIT_VAULT, or <config dir>/oait/vault.json) into its
+// keys by name. A missing vault has no keys.
+func Load(passphrase string) (map[string]string, error) {
+	fileName, err := Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return make(map[string]string), nil
+	}
+
+	if err != nil {
+		err = errors.New("Failed reading vault: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	var file vaultFile
+	err = json.Unmarshal(data, &file)
+
+	if err != nil || file.Version != version || file.KDF != "scrypt" {
+		errMsg := fmt.Sprintf("Failed reading vault: %v. Error: unsupported or corrupt vault file", fileName)
+		err = errors.New(errMsg)
+		return nil, err
+	}
+
+	gcm, err := newGCM(passphrase, file.Salt, file.N, file.R, file.P)
+
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+
+	if err != nil {
+		err = errors.New("Failed opening vault: " + fileName + ". Error: wrong passphrase or corrupt vault")
+		return nil, err
+	}
+
+	keys := make(map[string]string)
+	err = json.Unmarshal(plain, &keys)
+
+	if err != nil {
+		err = errors.New("Failed opening vault: " + fileName + ". Error: " + err.Error())
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// Save encrypts the keys into the vault with a fresh salt and nonce, readable only by the user.
+func Save(keys map[string]string, passphrase string) error {
+	fileName, err := Path()
+
+	if err != nil {
+		return err
+	}
+
+	plain, err := json.Marshal(keys)
+
+	if err != nil {
+		return err
+	}
+
+	file := vaultFile{
+		Version: version,
+		KDF:     "scrypt",
+		N:       scryptN,
+		R:       scryptR,
+		P:       scryptP,
+		Salt:    make([]byte, 16),
+	}
+
+	_, err = rand.Read(file.Salt)
+
+	if err != nil {
+		return err
+	}
+
+	gcm, err := newGCM(passphrase, file.Salt, file.N, file.R, file.P)
+
+	if err != nil {
+		return err
+	}
+
+	file.Nonce = make([]byte, gcm.NonceSize())
+	_, err = rand.Read(file.Nonce)
+
+	if err != nil {
+		return err
+	}
+
+	file.Data = gcm.Seal(nil, file.Nonce, plain, nil)
+
+	data, err := json.MarshalIndent(file, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(fileName), 0700)
+
+	if err == nil {
+		err = os.WriteFile(fileName, data, 0600)
+	}
+
+	if err != nil {
+		err = errors.New("Failed writing vault: " + fileName + ". Error: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Key is the named key from the vault.
+func Key(name string) (string, error) {
+	passphrase, err := Passphrase(false)
+
+	if err != nil {
+		return "", err
+	}
+
+	keys, err := Load(passphrase)
+
+	if err != nil {
+		return "", err
+	}
+
+	key, ok := keys[name]
+
+	if !ok {
+		errMsg := fmt.Sprintf("No key named '%v' in the vault. (see `oait vault list`)", name)
+		err = errors.New(errMsg)
+		return "", err
+	}
+
+	return key, nil
+}
+
+// Passphrase is OAIT_VAULT_PASSPHRASE, or read from the terminal without echoing.
+// With confirm it's asked for twice, for setting a new passphrase.
+func Passphrase(confirm bool) (string, error) {
+	passphrase := os.Getenv(PassphraseEnv)
+
+	if passphrase != "" {
+		return passphrase, nil
+	}
+
+	passphrase, err := ReadSecret("Vault passphrase: ")
+
+	if err != nil {
+		return "", err
+	}
+
+	if passphrase == "" {
+		err = errors.New("Empty vault passphrase")
+		return "", err
+	}
+
+	if confirm {
+		again, err := ReadSecret("Confirm passphrase: ")
+
+		if err != nil {
+			return "", err
+		}
+
+		if again != passphrase {
+			err = errors.New("Passphrases don't match")
+			return "", err
+		}
+	}
+
+	return passphrase, nil
+}
+
+// ReadSecret prompts on the terminal and reads a line without echoing it.
+func ReadSecret(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+
+	if !term.IsTerminal(fd) {
+		errMsg := fmt.Sprintf("Cannot prompt '%v': stdin is not a terminal (set %v)", prompt, PassphraseEnv)
+		err := errors.New(errMsg)
+		return "", err
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	secret, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(secret), nil
+}
+
+// Names lists the names of the keys, sorted.
+func Names(keys map[string]string) []string {
+	names := make([]string, 0, len(keys))
+
+	for name := range keys {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func Path() (string, error) {
+	fileName := os.Getenv(VaultEnv)
+
+	if fileName != "" {
+		return fileName, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+
+	if err != nil {
+		err = errors.New("Failed to locate vault: " + err.Error())
+		return "", err
+	}
+
+	return filepath.Join(configDir, "oait", "vault.json"), nil
+}
+
+func newGCM(passphrase string, salt []byte, n int, r int, p int) (cipher.AEAD, error) {
+	derived, err := scrypt.Key([]byte(passphrase), salt, n, r, p, 32)
+
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(derived)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}